@@ -0,0 +1,170 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"quickalias/internal/shell"
+	"quickalias/internal/ui"
+)
+
+// AdoptAliases brings aliases defined in the running shell, for example by plugins or
+// interactively, under qq management at the user level. It reads the shell's own listing
+// (`alias`, or fish's `functions` and `abbr --show`) from stdin, as piped by the qq-adopt
+// shell function. names picks aliases without asking; all picks every alias qq does not
+// have yet. Otherwise the user picks from a numbered list.
+func (qa *QuickAlias) AdoptAliases(shellType string, names []string, all bool) error {
+	if shellType == "" {
+		shellType = qa.Config.ShellType
+	}
+	if ui.IsTerminal(os.Stdin) {
+		return errors.New(ui.Msg.AdoptNeedsInput)
+	}
+	data, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return err
+	}
+
+	candidates := qa.importCandidates(shell.ParseAliasOutput(string(data), shellType == "fish"), true)
+	if len(candidates) == 0 {
+		fmt.Printf("%s%s%s\n", ui.Color.Yellow, ui.Msg.AdoptNothingFound, ui.Color.Reset)
+		return nil
+	}
+
+	var adoptable []importCandidate
+	managed := 0
+	for _, c := range candidates {
+		switch c.outcome {
+		case importNew, importOverwrite:
+			adoptable = append(adoptable, c)
+		case importManaged:
+			managed++
+		}
+	}
+	printAdoptable(adoptable, candidates, managed)
+	if len(adoptable) == 0 {
+		fmt.Printf("%s%s%s\n", ui.Color.Green, ui.Msg.ImportShellNothingToDo, ui.Color.Reset)
+		return nil
+	}
+
+	var selected []importCandidate
+	switch {
+	case len(names) > 0:
+		selected, err = selectAdoptable(names, adoptable)
+	case all || ui.Prompt.AssumeYes:
+		selected, err = selectAdoptable([]string{"all"}, adoptable)
+	default:
+		var answer string
+		answer, err = ui.Ask(fmt.Sprintf("%s%s%s", ui.Color.Yellow, ui.Msg.AdoptSelectPrompt, ui.Color.Reset))
+		if err == nil && answer == "" {
+			err = ui.ErrCancelled
+		}
+		if err == nil {
+			selected, err = selectAdoptable(strings.FieldsFunc(answer, func(r rune) bool { return r == ',' || r == ' ' }), adoptable)
+		}
+	}
+	if errors.Is(err, ui.ErrCancelled) {
+		fmt.Printf("%s❌ %s%s\n", ui.Color.Red, ui.Msg.OperationCancelled, ui.Color.Reset)
+	}
+	if err != nil {
+		return err
+	}
+	if len(selected) == 0 {
+		fmt.Printf("%s%s%s\n", ui.Color.Green, ui.Msg.ImportShellNothingToDo, ui.Color.Reset)
+		return nil
+	}
+
+	if err := qa.addImported(selected); err != nil {
+		return err
+	}
+	fmt.Printf("%s✅ %s%s\n", ui.Color.Green, fmt.Sprintf(ui.Msg.AdoptAdopted, len(selected)), ui.Color.Reset)
+	fmt.Printf("%s💡 %s%s\n", ui.Color.Cyan, ui.Msg.AdoptOriginalHint, ui.Color.Reset)
+	return nil
+}
+
+// printAdoptable prints the numbered list the user picks from, followed by the
+// definitions that cannot be adopted and the number already managed by qq.
+func printAdoptable(adoptable, candidates []importCandidate, managed int) {
+	if len(adoptable) > 0 {
+		fmt.Printf("%s%s%s\n", ui.Color.Cyan+ui.Color.Bold, ui.Msg.AdoptFound, ui.Color.Reset)
+	}
+	for i, c := range adoptable {
+		if c.outcome == importOverwrite {
+			fmt.Printf("  %s%3d) %s → %s  %s%s\n", ui.Color.Yellow, i+1, c.def.Name, c.def.Command,
+				fmt.Sprintf(ui.Msg.ImportShellOverwrites, c.existing.Level, c.existing.Command), ui.Color.Reset)
+		} else {
+			fmt.Printf("  %s%3d)%s %s → %s\n", ui.Color.Green, i+1, ui.Color.Reset, c.def.Name, c.def.Command)
+		}
+	}
+	for _, c := range candidates {
+		if c.outcome == importSkipped {
+			fmt.Printf("  %s  -  %s → %s  (%s)%s\n", ui.Color.Dim, c.def.Name, c.shownCommand(), c.reason, ui.Color.Reset)
+		}
+	}
+	if managed > 0 {
+		fmt.Printf("%s%s%s\n", ui.Color.White, fmt.Sprintf(ui.Msg.AdoptManaged, managed), ui.Color.Reset)
+	}
+	fmt.Println()
+}
+
+// selectAdoptable resolves the user's picks: numbers from the list, ranges such as 2-5,
+// alias names, or "all" for every alias qq does not have yet. Aliases that would replace
+// a different qq alias are only adopted when picked explicitly.
+func selectAdoptable(picks []string, adoptable []importCandidate) ([]importCandidate, error) {
+	chosen := make([]bool, len(adoptable))
+	for _, pick := range picks {
+		switch first, last, isRange := strings.Cut(pick, "-"); {
+		case pick == "all" || pick == "hepsi":
+			for i, c := range adoptable {
+				chosen[i] = chosen[i] || c.outcome == importNew
+			}
+		case isNumber(pick) || isRange && isNumber(first) && isNumber(last):
+			if !isRange {
+				last = first
+			}
+			from, _ := strconv.Atoi(first)
+			to, _ := strconv.Atoi(last)
+			if from < 1 || to > len(adoptable) || from > to {
+				return nil, fmt.Errorf(ui.Msg.AdoptInvalidPick, pick)
+			}
+			for i := from; i <= to; i++ {
+				chosen[i-1] = true
+			}
+		default:
+			found := false
+			for i, c := range adoptable {
+				if c.def.Name == pick {
+					chosen[i], found = true, true
+				}
+			}
+			if !found {
+				return nil, fmt.Errorf(ui.Msg.AdoptInvalidPick, pick)
+			}
+		}
+	}
+
+	var selected []importCandidate
+	for i, c := range adoptable {
+		if chosen[i] {
+			selected = append(selected, c)
+		}
+	}
+	return selected, nil
+}
+
+// isNumber reports whether s is a non-empty string of ASCII digits.
+func isNumber(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"quickalias/internal/alias"
+	"quickalias/internal/ui"
+)
+
+// levelPlan is what `qq apply` would change in one level.
+type levelPlan struct {
+	level     string
+	target    []alias.Alias // The level's aliases after applying.
+	changes   []alias.Change
+	unmanaged int  // Aliases not in the manifest that are kept.
+	pruneSkip bool // --prune was given, but the manifest has no aliases at this level.
+}
+
+// ApplyManifest makes the user and global aliases match a manifest file (JSON, YAML or
+// TOML, like `config import`). Aliases in the manifest are added or changed at their level,
+// user by default; aliases that are not in it are kept unless prune is set. Only levels the
+// manifest has aliases for are pruned, so a user manifest never removes global aliases. Nothing is
+// written when everything already matches. With check, it only reports the plan and
+// returns errDrift when there is something to change.
+func (qa *QuickAlias) ApplyManifest(path string, check, prune bool) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf(ui.Msg.ImportFileReadError, err)
+	}
+	manifest, err := alias.DecodeAliases(path, data)
+	if err != nil {
+		return fmt.Errorf(ui.Msg.ImportFileParseError, err)
+	}
+	for i := range manifest {
+		if manifest[i].Level == "" {
+			manifest[i].Level = "user"
+		}
+	}
+	if err := validateManifest(manifest, qa.Config.ShellType, "user", "global"); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var plans []levelPlan
+	total := 0
+	for _, level := range []string{"global", "user"} {
+		levelPrune := prune && hasLevel(manifest, level)
+		plan := planLevel(level, *qa.levelAliases(level), manifest, levelPrune, now)
+		plan.pruneSkip = prune && !levelPrune
+		plans = append(plans, plan)
+		total += len(plan.changes)
+	}
+
+	for _, plan := range plans {
+		printLevelPlan(plan)
+	}
+	// Writing global aliases needs root; the plan is shown first, so it can be reviewed before sudo asks.
+	if !check && len(plans[0].changes) > 0 && os.Geteuid() != 0 {
+		return errPrivilegesRequired
+	}
+	if total == 0 {
+		fmt.Printf("%s✅ %s%s\n", ui.Color.Green, ui.Msg.ApplyUpToDate, ui.Color.Reset)
+		return nil
+	}
+	if check {
+		fmt.Printf("%s%s%s\n", ui.Color.Yellow, fmt.Sprintf(ui.Msg.ApplyDrift, total), ui.Color.Reset)
+		return errDrift
+	}
+
+	if err := ui.Confirm(fmt.Sprintf("%s%s%s", ui.Color.Yellow, fmt.Sprintf(ui.Msg.ApplyConfirmation, total), ui.Color.Reset)); err != nil {
+		if errors.Is(err, ui.ErrCancelled) {
+			fmt.Printf("%s❌ %s%s\n", ui.Color.Red, ui.Msg.OperationCancelled, ui.Color.Reset)
+		}
+		return err
+	}
+	for _, plan := range plans {
+		if len(plan.changes) == 0 {
+			continue
+		}
+		qa.PersistManager.CreateBackup(plan.level, ui.Msg.ErrorProcessingBackupData, ui.Msg.ErrorWritingBackupFile)
+		*qa.levelAliases(plan.level) = plan.target
+		if err := qa.SaveAliases(plan.level); err != nil {
+			return err
+		}
+	}
+	fmt.Printf("%s✅ %s%s\n", ui.Color.Green, fmt.Sprintf(ui.Msg.ApplyApplied, total), ui.Color.Reset)
+	fmt.Printf("\n%s💡 %s %s%s%s\n", ui.Color.Cyan, ui.Msg.RestartTerminalHint, ui.Color.Bold, ui.Msg.RestartTerminalCmdHint, ui.Color.Reset)
+	return nil
+}
+
+// validateManifest rejects invalid names, levels other than the given ones, names defined
+// twice at the same level and secret placeholders in pack and global aliases.
+func validateManifest(manifest []alias.Alias, shellType string, levels ...string) error {
+	errs := alias.ValidateAliases(manifest, shellType)
+	seen := make(map[string]bool)
+	for _, a := range manifest {
+		if !slices.Contains(levels, a.Level) {
+			errs = append(errs, fmt.Errorf(ui.Msg.ApplyUnknownLevel, a.Name, a.Level, strings.Join(levels, ", ")))
+		}
+		key := a.Level + "/" + a.Name
+		if seen[key] {
+			errs = append(errs, fmt.Errorf(ui.Msg.ApplyDuplicate, a.Name, a.Level))
+		}
+		seen[key] = true
+		if err := alias.CheckSecretLevel(a); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		for _, err := range errs {
+			fmt.Printf("%s❌ %v%s\n", ui.Color.Red, err, ui.Color.Reset)
+		}
+		return fmt.Errorf(ui.Msg.ImportValidationFailed, len(errs))
+	}
+	for _, a := range manifest {
+		alias.PrintCommandWarnings(a.Name, alias.CheckCommand(a.Command))
+	}
+	return nil
+}
+
+// planLevel computes the aliases of one level after applying the manifest. Aliases that
+// are unchanged keep their stored form, changed ones keep their creation provenance.
+func planLevel(level string, current, manifest []alias.Alias, prune bool, now time.Time) levelPlan {
+	plan := levelPlan{level: level}
+	if !prune {
+		plan.target = append(plan.target, current...)
+	}
+	inManifest := make(map[string]bool)
+	for _, wanted := range manifest {
+		if wanted.Level != level {
+			continue
+		}
+		inManifest[wanted.Name] = true
+		existing, exists := alias.FindAlias(wanted.Name, current)
+		next := existing
+		if !exists || !existing.SameDefinition(wanted) {
+			next = wanted
+			if exists {
+				alias.Stamp(&next, &existing, now)
+			} else {
+				alias.Stamp(&next, nil, now)
+			}
+		}
+		if i := indexOf(wanted.Name, plan.target); i >= 0 {
+			plan.target[i] = next
+		} else {
+			plan.target = append(plan.target, next)
+		}
+	}
+	for _, a := range current {
+		if !prune && !inManifest[a.Name] {
+			plan.unmanaged++
+		}
+	}
+	plan.changes = alias.Diff(current, plan.target)
+	return plan
+}
+
+// hasLevel reports whether the manifest has aliases at level.
+func hasLevel(manifest []alias.Alias, level string) bool {
+	for _, a := range manifest {
+		if a.Level == level {
+			return true
+		}
+	}
+	return false
+}
+
+// indexOf returns the position of the alias called name in aliases, or -1.
+func indexOf(name string, aliases []alias.Alias) int {
+	for i, a := range aliases {
+		if a.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// printLevelPlan prints the changes planned for one level.
+func printLevelPlan(plan levelPlan) {
+	title := ui.Msg.UserAliasesHeader
+	if plan.level == "global" {
+		title = ui.Msg.GlobalAliasesHeader
+	}
+	fmt.Printf("%s%s%s\n", ui.Color.Cyan+ui.Color.Bold, title, ui.Color.Reset)
+	if len(plan.changes) == 0 {
+		fmt.Printf("  %s%s%s\n", ui.Color.White, ui.Msg.ApplyNoChanges, ui.Color.Reset)
+	}
+	alias.PrintChanges(plan.changes)
+	if plan.unmanaged > 0 {
+		msg := ui.Msg.ApplyUnmanagedKept
+		if plan.pruneSkip {
+			msg = ui.Msg.ApplyLevelNotPruned
+		}
+		fmt.Printf("  %s%s%s\n", ui.Color.Dim, fmt.Sprintf(msg, plan.unmanaged), ui.Color.Reset)
+	}
+	fmt.Println()
+}
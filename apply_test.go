@@ -0,0 +1,178 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"quickalias/internal/alias"
+	"quickalias/internal/ui"
+)
+
+func TestPlanLevel(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	current := []alias.Alias{
+		{Name: "ll", Command: "ls -l", Level: "user", Created: "2026-01-01T00:00:00Z"},
+		{Name: "gs", Command: "git status", Level: "user"},
+		{Name: "up", Command: "cd ..", Level: "user"},
+	}
+	tests := []struct {
+		name      string
+		manifest  []alias.Alias
+		prune     bool
+		target    []string
+		changes   map[string]string // Name to kind of change.
+		unmanaged int
+	}{
+		{"up to date", []alias.Alias{{Name: "ll", Command: "ls -l", Level: "user"}}, false,
+			[]string{"ll", "gs", "up"}, map[string]string{}, 2},
+		{"add and change", []alias.Alias{{Name: "ll", Command: "ls -la", Level: "user"}, {Name: "k", Command: "kubectl", Level: "user"}}, false,
+			[]string{"ll", "gs", "up", "k"}, map[string]string{"ll": alias.ChangeUpdate, "k": alias.ChangeAdd}, 2},
+		{"prune", []alias.Alias{{Name: "ll", Command: "ls -l", Level: "user"}, {Name: "k", Command: "kubectl", Level: "user"}}, true,
+			[]string{"ll", "k"}, map[string]string{"gs": alias.ChangeRemove, "up": alias.ChangeRemove, "k": alias.ChangeAdd}, 0},
+		{"other level only", []alias.Alias{{Name: "ll", Command: "ls", Level: "global"}}, false,
+			[]string{"ll", "gs", "up"}, map[string]string{}, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := planLevel("user", current, tt.manifest, tt.prune, now)
+			var names []string
+			for _, a := range plan.target {
+				names = append(names, a.Name)
+			}
+			if !reflect.DeepEqual(names, tt.target) {
+				t.Errorf("target = %v, want %v", names, tt.target)
+			}
+			changes := make(map[string]string)
+			for _, c := range plan.changes {
+				changes[c.Name] = c.Kind
+			}
+			if !reflect.DeepEqual(changes, tt.changes) {
+				t.Errorf("changes = %v, want %v", changes, tt.changes)
+			}
+			if plan.unmanaged != tt.unmanaged {
+				t.Errorf("unmanaged = %d, want %d", plan.unmanaged, tt.unmanaged)
+			}
+		})
+	}
+
+	// A changed alias keeps its creation date; an unchanged one is kept as stored.
+	plan := planLevel("user", current, []alias.Alias{{Name: "ll", Command: "ls -la", Level: "user"}, {Name: "gs", Command: "git status", Level: "user"}}, false, now)
+	if ll, _ := alias.FindAlias("ll", plan.target); ll.Created != "2026-01-01T00:00:00Z" || ll.Updated == "" {
+		t.Errorf("changed alias = %+v", ll)
+	}
+	if gs, _ := alias.FindAlias("gs", plan.target); !reflect.DeepEqual(gs, current[1]) {
+		t.Errorf("unchanged alias = %+v, want %+v", gs, current[1])
+	}
+}
+
+func TestApplyManifest(t *testing.T) {
+	manifest := `[{"name": "ll", "command": "ls -l"}, {"name": "gs", "command": "git status"}]`
+	tests := []struct {
+		name     string
+		existing []alias.Alias
+		manifest string
+		check    bool
+		prune    bool
+		err      error // Wanted error, checked with errors.Is.
+		invalid  int   // Number of aliases the manifest is refused for.
+		want     []string
+	}{
+		{name: "add", manifest: manifest, want: []string{"ll", "gs"}},
+		{name: "check with drift", manifest: manifest, check: true, err: errDrift},
+		{name: "check up to date", existing: []alias.Alias{{Name: "ll", Command: "ls -l", Level: "user"}, {Name: "gs", Command: "git status", Level: "user"}},
+			manifest: manifest, check: true, want: []string{"ll", "gs"}},
+		{name: "keep unmanaged", existing: []alias.Alias{{Name: "up", Command: "cd ..", Level: "user"}},
+			manifest: manifest, want: []string{"up", "ll", "gs"}},
+		{name: "prune", existing: []alias.Alias{{Name: "up", Command: "cd ..", Level: "user"}},
+			manifest: manifest, prune: true, want: []string{"ll", "gs"}},
+		{name: "unknown level", manifest: `[{"name": "ll", "command": "ls", "level": "globl"}]`, invalid: 1},
+		{name: "pack level", manifest: `[{"name": "ll", "command": "ls", "level": "pack:k8s"}]`, invalid: 1},
+		{name: "duplicate", manifest: `[{"name": "ll", "command": "ls"}, {"name": "ll", "command": "ls -l", "level": "user"}]`, invalid: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			qa := newTestQuickAlias(t)
+			if tt.existing != nil {
+				qa.UserAliases = tt.existing
+				if err := qa.SaveAliases("user"); err != nil {
+					t.Fatal(err)
+				}
+			}
+			path := filepath.Join(t.TempDir(), "manifest.json")
+			if err := os.WriteFile(path, []byte(tt.manifest), 0600); err != nil {
+				t.Fatal(err)
+			}
+			before := snapshot(t, qa.UserConfigPath)
+
+			err := qa.ApplyManifest(path, tt.check, tt.prune)
+			switch {
+			case tt.err != nil:
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("err = %v, want %v", err, tt.err)
+				}
+			case tt.invalid > 0:
+				if want := fmt.Sprintf(ui.Msg.ImportValidationFailed, tt.invalid); err == nil || err.Error() != want {
+					t.Fatalf("err = %v, want %q", err, want)
+				}
+			case err != nil:
+				t.Fatalf("ApplyManifest: %v", err)
+			}
+			if tt.err != nil || tt.invalid > 0 || tt.check {
+				if after := snapshot(t, qa.UserConfigPath); !reflect.DeepEqual(after, before) {
+					t.Errorf("files changed from %v to %v", before, after)
+				}
+			}
+			if tt.want != nil {
+				var names []string
+				for _, a := range qa.UserAliases {
+					names = append(names, a.Name)
+				}
+				if !reflect.DeepEqual(names, tt.want) {
+					t.Errorf("user aliases = %v, want %v", names, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestApplyManifestUpToDateWritesNothing(t *testing.T) {
+	qa := newTestQuickAlias(t)
+	path := filepath.Join(t.TempDir(), "manifest.yaml")
+	if err := os.WriteFile(path, []byte("- name: ll\n  command: ls -l\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := qa.ApplyManifest(path, false, false); err != nil {
+		t.Fatalf("first ApplyManifest: %v", err)
+	}
+	before := snapshot(t, qa.UserConfigPath)
+	if err := qa.ApplyManifest(path, false, true); err != nil {
+		t.Fatalf("second ApplyManifest: %v", err)
+	}
+	if after := snapshot(t, qa.UserConfigPath); !reflect.DeepEqual(after, before) {
+		t.Errorf("files changed from %v to %v", before, after)
+	}
+}
+
+// snapshot returns the content of every file under dir by relative path.
+func snapshot(t *testing.T, dir string) map[string]string {
+	t.Helper()
+	files := make(map[string]string)
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		rel, _ := filepath.Rel(dir, path)
+		files[rel] = string(data)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return files
+}
@@ -0,0 +1,440 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"quickalias/internal/alias"
+	"quickalias/internal/cli"
+	"quickalias/internal/config"
+	"quickalias/internal/shell"
+	"quickalias/internal/ui"
+)
+
+// commandTable describes every qq command. It is the single source for
+// dispatching, `qq help` and shell completion.
+func (qa *QuickAlias) commandTable() []*cli.Command {
+	var commands []*cli.Command
+	commands = []*cli.Command{
+		{
+			Name: "add", Group: ui.Msg.UsageAliasManagement, Usage: "<alias> <command...> | <alias>=<command>",
+			Summary: ui.Msg.CmdAddSummary, Description: ui.Msg.CmdAddDescription,
+			MinArgs: 1, MaxArgs: -1, StopAtFirstArg: true, Flags: addFlags(),
+			Run: func(ctx *cli.Context) error { return qa.runAdd(ctx, "user") },
+		},
+		{
+			Name: "set", Group: ui.Msg.UsageAliasManagement, Usage: "<alias> <command...> | <alias>=<command>",
+			Summary: ui.Msg.CmdSetSummary, Description: ui.Msg.CmdAddDescription,
+			MinArgs: 1, MaxArgs: -1, StopAtFirstArg: true, Privileged: true, Flags: addFlags(),
+			Run: func(ctx *cli.Context) error { return qa.runAdd(ctx, "global") },
+		},
+		{
+			Name: "edit", Group: ui.Msg.UsageAliasManagement, Usage: "[alias]", Summary: ui.Msg.CmdEditSummary,
+			Description: ui.Msg.CmdEditDescription, Args: []string{cli.CompleteUserAliases}, MaxArgs: 1,
+			Run: func(ctx *cli.Context) error {
+				name := ""
+				if len(ctx.Args) > 0 {
+					name = ctx.Args[0]
+				}
+				return qa.EditAliases(name)
+			},
+		},
+		{
+			Name: "remove", Group: ui.Msg.UsageAliasManagement, Usage: "<alias>", Summary: ui.Msg.CmdRemoveSummary,
+			Args: []string{cli.CompleteUserAliases}, MinArgs: 1, MaxArgs: 1,
+			Run: func(ctx *cli.Context) error { return qa.RemoveAlias(ctx.Args[0], "user") },
+		},
+		{
+			Name: "unset", Group: ui.Msg.UsageAliasManagement, Usage: "<alias>", Summary: ui.Msg.CmdUnsetSummary,
+			Args: []string{cli.CompleteGlobalAliases}, MinArgs: 1, MaxArgs: 1, Privileged: true,
+			Run: func(ctx *cli.Context) error { return qa.RemoveAlias(ctx.Args[0], "global") },
+		},
+		{
+			Name: "rename", Group: ui.Msg.UsageAliasManagement, Usage: "<old> <new>", Summary: ui.Msg.CmdRenameSummary,
+			Args: []string{cli.CompleteAliases}, MinArgs: 2, MaxArgs: 2, Flags: levelFlags(), PrivilegedFlag: "global",
+			Run: func(ctx *cli.Context) error { return qa.RenameAlias(ctx.Args[0], ctx.Args[1], levelOf(ctx)) },
+		},
+		{
+			Name: "cp", Aliases: []string{"copy"}, Group: ui.Msg.UsageAliasManagement, Usage: "<alias> <new>", Summary: ui.Msg.CmdCopySummary,
+			Args: []string{cli.CompleteAliases}, MinArgs: 2, MaxArgs: 2, Flags: levelFlags(), PrivilegedFlag: "global",
+			Run: func(ctx *cli.Context) error { return qa.CopyAlias(ctx.Args[0], ctx.Args[1], levelOf(ctx)) },
+		},
+		{
+			Name: "promote", Group: ui.Msg.UsageAliasManagement, Usage: "<alias>", Summary: ui.Msg.CmdPromoteSummary,
+			Args: []string{cli.CompleteUserAliases}, MinArgs: 1, MaxArgs: 1, Privileged: true,
+			Run: func(ctx *cli.Context) error { return qa.MoveAlias(ctx.Args[0], "user", "global") },
+		},
+		{
+			Name: "demote", Group: ui.Msg.UsageAliasManagement, Usage: "<alias>", Summary: ui.Msg.CmdDemoteSummary,
+			Args: []string{cli.CompleteGlobalAliases}, MinArgs: 1, MaxArgs: 1, Privileged: true,
+			Run: func(ctx *cli.Context) error { return qa.MoveAlias(ctx.Args[0], "global", "user") },
+		},
+		{
+			Name: "disable", Group: ui.Msg.UsageAliasManagement, Usage: "<alias>", Summary: ui.Msg.CmdDisableSummary,
+			Args: []string{cli.CompleteAliases}, MinArgs: 1, MaxArgs: 1, Flags: levelFlags(), PrivilegedFlag: "global",
+			Run: func(ctx *cli.Context) error { return qa.SetAliasEnabled(ctx.Args[0], levelOf(ctx), false) },
+		},
+		{
+			Name: "enable", Group: ui.Msg.UsageAliasManagement, Usage: "<alias>", Summary: ui.Msg.CmdEnableSummary,
+			Args: []string{cli.CompleteAliases}, MinArgs: 1, MaxArgs: 1, Flags: levelFlags(), PrivilegedFlag: "global",
+			Run: func(ctx *cli.Context) error { return qa.SetAliasEnabled(ctx.Args[0], levelOf(ctx), true) },
+		},
+		{
+			Name: "list", Group: ui.Msg.UsageListingSearching, Usage: "[keyword]", Summary: ui.Msg.CmdListSummary,
+			MaxArgs: 1,
+			Flags: []*cli.Flag{
+				{Name: "tag", Kind: cli.StringsFlag, Value: "<tag>", Complete: cli.CompleteTags, Usage: ui.Msg.FlagListTagSummary},
+				{Name: "category", Kind: cli.StringFlag, Value: "<category>", Complete: cli.CompleteCategories, Usage: ui.Msg.FlagListCategorySummary},
+				{Name: "sort", Kind: cli.StringFlag, Value: "<name|created|updated>", Values: alias.SortKeys, Usage: ui.Msg.FlagSortSummary},
+			},
+			Run: func(ctx *cli.Context) error {
+				filter := alias.Filter{Tags: alias.NormalizeTags(ctx.Strings("tag")), Category: ctx.String("category")}
+				if len(ctx.Args) > 0 {
+					filter.Keyword = ctx.Args[0]
+				}
+				return qa.ListAliases(filter, ctx.String("sort"))
+			},
+		},
+		{
+			Name: "show", Group: ui.Msg.UsageListingSearching, Usage: "<alias>", Summary: ui.Msg.CmdShowSummary,
+			Args: []string{cli.CompleteAliases}, MinArgs: 1, MaxArgs: 1,
+			Run: func(ctx *cli.Context) error { return qa.ShowAlias(ctx.Args[0]) },
+		},
+		{
+			Name: "which", Group: ui.Msg.UsageListingSearching, Usage: "<alias>", Summary: ui.Msg.CmdWhichSummary,
+			Args: []string{cli.CompleteAliases}, MinArgs: 1, MaxArgs: 1,
+			Run: func(ctx *cli.Context) error { return qa.WhichAlias(ctx.Args[0]) },
+		},
+		{
+			Name: "search", Group: ui.Msg.UsageListingSearching, Usage: "<keyword>", Summary: ui.Msg.CmdSearchSummary,
+			MinArgs: 1, MaxArgs: 1,
+			Run: func(ctx *cli.Context) error { return qa.SearchAliases(ctx.Args[0]) },
+		},
+		{
+			Name: "status", Aliases: []string{"control"}, Group: ui.Msg.UsageSystem, Summary: ui.Msg.CmdStatusSummary,
+			Run: func(ctx *cli.Context) error { return qa.ShowStatus() },
+		},
+		{
+			Name: "doctor", Group: ui.Msg.UsageSystem, Summary: ui.Msg.CmdDoctorSummary, SkipInit: true,
+			Run: func(ctx *cli.Context) error { return qa.Doctor() },
+		},
+		{
+			Name: "setup", Group: ui.Msg.UsageSystem, Summary: ui.Msg.CmdSetupSummary, SkipInit: true,
+			Run: func(ctx *cli.Context) error { return qa.Setup() },
+		},
+		{
+			Name: "init", Group: ui.Msg.UsageSystem, Summary: ui.Msg.CmdInitSummary, SkipInit: true,
+			Run: func(ctx *cli.Context) error { return qa.Init() },
+		},
+		{
+			Name: "completion", Group: ui.Msg.UsageSystem, Usage: "<bash|zsh|fish>", Summary: ui.Msg.CmdCompletionSummary,
+			Args: []string{cli.CompleteShells}, MinArgs: 1, MaxArgs: 1, SkipInit: true,
+			Run: func(ctx *cli.Context) error { return qa.Completion(ctx.Args[0]) },
+		},
+		{
+			Name: "apply", Group: ui.Msg.UsageConfiguration, Usage: "<manifest>", Summary: ui.Msg.CmdApplySummary,
+			Description: ui.Msg.CmdApplyDescription, Args: []string{cli.CompleteFiles}, MinArgs: 1, MaxArgs: 1,
+			Flags: []*cli.Flag{
+				{Name: "check", Kind: cli.BoolFlag, Usage: ui.Msg.FlagApplyCheckSummary},
+				{Name: "prune", Kind: cli.BoolFlag, Usage: ui.Msg.FlagApplyPruneSummary},
+			},
+			Run: func(ctx *cli.Context) error {
+				return qa.ApplyManifest(ctx.Args[0], ctx.Bool("check"), ctx.Bool("prune"))
+			},
+		},
+		{
+			Name: "import-shell", Group: ui.Msg.UsageConfiguration, Usage: "[file]", Summary: ui.Msg.CmdImportShellSummary,
+			Description: ui.Msg.CmdImportShellDescription, Args: []string{cli.CompleteFiles}, MaxArgs: 1,
+			Flags: []*cli.Flag{
+				{Name: "overwrite", Kind: cli.BoolFlag, Usage: ui.Msg.FlagOverwriteSummary},
+				{Name: "comment-out", Kind: cli.BoolFlag, Usage: ui.Msg.FlagCommentOutSummary},
+			},
+			Run: func(ctx *cli.Context) error {
+				path := ""
+				if len(ctx.Args) > 0 {
+					path = ctx.Args[0]
+				}
+				return qa.ImportShell(path, ctx.Bool("overwrite"), ctx.Bool("comment-out"))
+			},
+		},
+		{
+			Name: "adopt", Group: ui.Msg.UsageConfiguration, Usage: "[alias...]", Summary: ui.Msg.CmdAdoptSummary,
+			Description: ui.Msg.CmdAdoptDescription, MaxArgs: -1,
+			Flags: []*cli.Flag{
+				{Name: "all", Kind: cli.BoolFlag, Usage: ui.Msg.FlagAdoptAllSummary},
+				{Name: "shell", Kind: cli.StringFlag, Value: "<shell>", Values: cli.CompletionShells, Usage: ui.Msg.FlagAdoptShellSummary},
+			},
+			Run: func(ctx *cli.Context) error { return qa.AdoptAliases(ctx.String("shell"), ctx.Args, ctx.Bool("all")) },
+		},
+		{
+			Name: "pack", Group: ui.Msg.UsageConfiguration, Usage: "<subcommand>", Summary: ui.Msg.CmdPackSummary,
+			Description: ui.Msg.CmdPackDescription,
+			Subcommands: []*cli.Command{
+				{
+					Name: "install", Usage: "<path|archive.tar.gz|name[@version]>", Summary: ui.Msg.CmdPackInstallSummary,
+					Args: []string{cli.CompleteFiles}, MinArgs: 1, MaxArgs: 1,
+					Run: func(ctx *cli.Context) error { return qa.InstallPack(ctx.Args[0]) },
+				},
+				{
+					Name: "update", Usage: "[pack...]", Summary: ui.Msg.CmdPackUpdateSummary,
+					Args: []string{cli.CompletePacks}, MaxArgs: -1,
+					Run: func(ctx *cli.Context) error { return qa.UpdatePacks(ctx.Args) },
+				},
+				{
+					Name: "add-source", Usage: "<url>", Summary: ui.Msg.CmdPackAddSourceSummary,
+					Description: ui.Msg.CmdPackAddSourceDescription, MinArgs: 1, MaxArgs: 1,
+					Flags: []*cli.Flag{
+						{Name: "key", Kind: cli.StringFlag, Value: "<public-key>", Usage: ui.Msg.FlagPackKeySummary},
+					},
+					Run: func(ctx *cli.Context) error { return qa.AddPackSource(ctx.Args[0], ctx.String("key")) },
+				},
+				{
+					Name: "list", Summary: ui.Msg.CmdPackListSummary,
+					Run: func(ctx *cli.Context) error { return qa.ListPacks() },
+				},
+				{
+					Name: "remove", Usage: "<pack>", Summary: ui.Msg.CmdPackRemoveSummary,
+					Args: []string{cli.CompletePacks}, MinArgs: 1, MaxArgs: 1,
+					Run: func(ctx *cli.Context) error { return qa.RemovePack(ctx.Args[0]) },
+				},
+			},
+		},
+		{
+			Name: "secret", Group: ui.Msg.UsageConfiguration, Usage: "<subcommand>", Summary: ui.Msg.CmdSecretSummary,
+			Description: ui.Msg.CmdSecretDescription,
+			Subcommands: []*cli.Command{
+				{
+					Name: "set", Usage: "<name>", Summary: ui.Msg.CmdSecretSetSummary, MinArgs: 1, MaxArgs: 1,
+					Run: func(ctx *cli.Context) error { return qa.SetSecret(ctx.Args[0]) },
+				},
+				{
+					Name: "list", Summary: ui.Msg.CmdSecretListSummary,
+					Run: func(ctx *cli.Context) error { return qa.ListSecrets() },
+				},
+				{
+					Name: "remove", Usage: "<name>", Summary: ui.Msg.CmdSecretRemoveSummary, MinArgs: 1, MaxArgs: 1,
+					Run: func(ctx *cli.Context) error { return qa.RemoveSecret(ctx.Args[0]) },
+				},
+			},
+		},
+		{
+			Name: "config", Group: ui.Msg.UsageConfiguration, Usage: "<subcommand>", Summary: ui.Msg.CmdConfigSummary,
+			Subcommands: []*cli.Command{
+				{
+					Name: "reset", Summary: ui.Msg.CmdConfigResetSummary,
+					Run: func(ctx *cli.Context) error { return config.ResetConfig(&qa.Config, VERSION) },
+				},
+				{
+					Name: "backup", Summary: ui.Msg.CmdConfigBackupSummary,
+					Run: func(ctx *cli.Context) error { return qa.PersistManager.ShowBackups() },
+				},
+				{
+					Name: "export", Usage: "[path]", Summary: ui.Msg.CmdConfigExportSummary,
+					Description: ui.Msg.CmdConfigExportDescription, Args: []string{cli.CompleteFiles}, MaxArgs: 1,
+					Flags: []*cli.Flag{
+						{Name: "format", Kind: cli.StringFlag, Value: "<format>", Values: alias.ExportFormats, Usage: ui.Msg.FlagExportFormatSummary},
+					},
+					Run: func(ctx *cli.Context) error { return qa.ExportConfig(ctx.Args, ctx.String("format")) },
+				},
+				{
+					Name: "import", Usage: "<path>", Summary: ui.Msg.CmdConfigImportSummary,
+					Args: []string{cli.CompleteFiles}, MinArgs: 1, MaxArgs: 1,
+					Run: func(ctx *cli.Context) error { return qa.PersistManager.ImportConfig(ctx.Args[0], qa.Config.ShellType) },
+				},
+			},
+		},
+		{
+			Name: "version", Group: ui.Msg.UsageOther, Summary: ui.Msg.CmdVersionSummary, SkipInit: true,
+			Run: func(ctx *cli.Context) error {
+				fmt.Printf("%sQuickAlias (qq) versiyon %s%s\n", ui.Color.Green+ui.Color.Bold, VERSION, ui.Color.Reset)
+				return nil
+			},
+		},
+		{
+			Name: "help", Aliases: []string{"--help", "-h"}, Group: ui.Msg.UsageOther, Usage: "[command]", Summary: ui.Msg.CmdHelpSummary,
+			MaxArgs: -1, SkipInit: true,
+			Run: func(ctx *cli.Context) error { return cli.PrintHelp(commands, ctx.Args) },
+		},
+		{
+			Name: "__complete", Hidden: true, RawArgs: true, SkipInit: true,
+			Run: func(ctx *cli.Context) error { return qa.Complete(ctx.Args) },
+		},
+	}
+	return commands
+}
+
+// addFlags returns the flags shared by `qq add` and `qq set`.
+func addFlags() []*cli.Flag {
+	return []*cli.Flag{
+		{Name: "allow-shadow", Kind: cli.BoolFlag, Usage: ui.Msg.FlagAllowShadowSummary},
+		{Name: "desc", Kind: cli.StringFlag, Value: "<text>", Usage: ui.Msg.FlagDescSummary},
+		{Name: "tag", Kind: cli.StringsFlag, Value: "<tag>", Complete: cli.CompleteTags, Usage: ui.Msg.FlagTagSummary},
+		{Name: "category", Kind: cli.StringFlag, Value: "<category>", Complete: cli.CompleteCategories, Usage: ui.Msg.FlagCategorySummary},
+		{Name: "if-host", Kind: cli.StringsFlag, Value: "<glob>", Usage: ui.Msg.FlagIfHostSummary},
+		{Name: "if-os", Kind: cli.StringsFlag, Value: "<os>", Values: []string{"linux", "darwin", "freebsd", "openbsd", "netbsd"}, Usage: ui.Msg.FlagIfOSSummary},
+		{Name: "if-shell", Kind: cli.StringsFlag, Value: "<shell>", Values: cli.CompletionShells, Usage: ui.Msg.FlagIfShellSummary},
+		{Name: "if-command", Kind: cli.StringsFlag, Value: "<command>", Usage: ui.Msg.FlagIfCommandSummary},
+		{Name: "if-env", Kind: cli.StringsFlag, Value: "<VAR[=value]>", Usage: ui.Msg.FlagIfEnvSummary},
+	}
+}
+
+// levelFlags returns the --global flag of commands that work on either level.
+func levelFlags() []*cli.Flag {
+	return []*cli.Flag{
+		{Name: "global", Short: "g", Kind: cli.BoolFlag, Usage: ui.Msg.FlagGlobalSummary},
+	}
+}
+
+// levelOf returns the level selected with --global.
+func levelOf(ctx *cli.Context) string {
+	if ctx.Bool("global") {
+		return "global"
+	}
+	return "user"
+}
+
+// runAdd implements `qq add` and `qq set`, accepting both "name command..." and "name=command".
+func (qa *QuickAlias) runAdd(ctx *cli.Context, level string) error {
+	name, command, err := parseAliasDefinition(ctx.Args)
+	if err != nil {
+		return err
+	}
+	return qa.AddAlias(alias.Alias{
+		Name:        name,
+		Command:     command,
+		Level:       level,
+		AllowShadow: ctx.Bool("allow-shadow"),
+		Description: strings.TrimSpace(ctx.String("desc")),
+		Tags:        alias.NormalizeTags(ctx.Strings("tag")),
+		Category:    strings.TrimSpace(ctx.String("category")),
+		Conditions:  conditionsFrom(ctx),
+	})
+}
+
+// conditionsFrom builds the alias conditions from the --if-* flags, or nil if none were given.
+func conditionsFrom(ctx *cli.Context) *alias.Conditions {
+	conditions := &alias.Conditions{
+		Hosts:    alias.SplitList(ctx.Strings("if-host")...),
+		OS:       alias.SplitList(ctx.Strings("if-os")...),
+		Shells:   alias.SplitList(ctx.Strings("if-shell")...),
+		Requires: alias.SplitList(ctx.Strings("if-command")...),
+		Env:      alias.SplitList(ctx.Strings("if-env")...),
+	}
+	if conditions.IsZero() {
+		return nil
+	}
+	return conditions
+}
+
+// parseAliasDefinition splits the arguments of `qq add` into an alias name and command.
+// A single command argument is taken verbatim; several arguments are re-quoted so that
+// quoting given on the command line survives, e.g. `qq add gc git commit -m "wip fix"`.
+func parseAliasDefinition(args []string) (string, string, error) {
+	first := args[0]
+	if i := strings.Index(first, "="); i > 0 && !strings.ContainsAny(first[:i], " \t") {
+		command := first[i+1:]
+		if len(args) > 1 {
+			command = strings.TrimSpace(command + " " + shell.JoinArgs(args[1:]))
+		}
+		if command == "" {
+			return "", "", fmt.Errorf(ui.Msg.InvalidAliasDefinition, first)
+		}
+		return first[:i], command, nil
+	}
+
+	switch len(args) {
+	case 1:
+		return "", "", fmt.Errorf(ui.Msg.InvalidAliasDefinition, first)
+	case 2:
+		return first, args[1], nil
+	default:
+		return first, shell.JoinArgs(args[1:]), nil
+	}
+}
+
+// ExportConfig writes all aliases to the given path, or to ~/quickalias_export.<ext> by default.
+// Without a format, it follows the extension of the path and defaults to JSON.
+func (qa *QuickAlias) ExportConfig(args []string, format string) error {
+	if format == "" && len(args) > 0 {
+		format = alias.ExportFormatFor(args[0])
+	} else if format == "" {
+		format = alias.ExportJSON
+	}
+	exportPath := filepath.Join(os.Getenv("HOME"), "quickalias_export"+alias.ExportExtension(format)) // Default export path.
+	if len(args) > 0 {
+		exportPath = args[0] // User-specified export path.
+		if !filepath.IsAbs(exportPath) {
+			currentDir, _ := os.Getwd()
+			exportPath = filepath.Join(currentDir, exportPath)
+		}
+	}
+	return qa.PersistManager.ExportConfig(exportPath, format)
+}
+
+// Completion prints the completion script for the requested shell.
+func (qa *QuickAlias) Completion(shellType string) error {
+	script, err := cli.GenerateCompletion(shellType, filepath.Base(os.Args[0]))
+	if err != nil {
+		return err
+	}
+	fmt.Print(script)
+	return nil
+}
+
+// Complete implements the hidden `qq __complete` endpoint used by the completion scripts.
+// It prints one candidate per line as "value<TAB>description".
+func (qa *QuickAlias) Complete(words []string) error {
+	candidates, files := cli.Complete(qa.commandTable(), words, qa.completionValues)
+	if files {
+		fmt.Println(cli.FilesDirective)
+		return nil
+	}
+	for _, c := range candidates {
+		if c.Description != "" {
+			fmt.Printf("%s\t%s\n", c.Value, c.Description)
+		} else {
+			fmt.Println(c.Value)
+		}
+	}
+	return nil
+}
+
+// completionValues resolves a completion kind to the current values.
+func (qa *QuickAlias) completionValues(kind string) []string {
+	var values []string
+	switch kind {
+	case cli.CompleteUserAliases:
+		values = aliasNames(qa.UserAliases)
+	case cli.CompleteGlobalAliases:
+		values = aliasNames(qa.GlobalAliases)
+	case cli.CompleteAliases:
+		values = append(aliasNames(qa.GlobalAliases), aliasNames(qa.UserAliases)...)
+	case cli.CompleteBackups:
+		files, _ := qa.PersistManager.ListBackups()
+		for _, file := range files {
+			values = append(values, filepath.Base(file))
+		}
+	case cli.CompletePacks:
+		for _, p := range qa.PersistManager.Packs {
+			values = append(values, p.Name)
+		}
+	case cli.CompleteShells:
+		values = cli.CompletionShells
+	case cli.CompleteTags:
+		values = alias.Tags(append(append([]alias.Alias{}, qa.GlobalAliases...), qa.UserAliases...))
+	case cli.CompleteCategories:
+		values = alias.Categories(append(append([]alias.Alias{}, qa.GlobalAliases...), qa.UserAliases...))
+	}
+	return values
+}
+
+// aliasNames returns the names of the given aliases.
+func aliasNames(aliases []alias.Alias) []string {
+	names := make([]string, 0, len(aliases))
+	for _, a := range aliases {
+		names = append(names, a.Name)
+	}
+	return names
+}
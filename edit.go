@@ -0,0 +1,145 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"quickalias/internal/alias"
+	"quickalias/internal/ui"
+)
+
+// EditAliases opens the user aliases, or only the named one, in the user's editor.
+// The result is parsed and validated (re-opening the editor on errors), shown as a
+// diff and saved with a backup once confirmed.
+func (qa *QuickAlias) EditAliases(name string) error {
+	editing := qa.UserAliases
+	if name != "" {
+		editing = []alias.Alias{{Name: name}} // Template for a new alias.
+		if a, ok := alias.FindAlias(name, qa.UserAliases); ok {
+			editing = []alias.Alias{a}
+		}
+	}
+
+	tmp, err := os.CreateTemp("", "qq-edit-*.txt")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	_, err = tmp.WriteString(alias.RenderEditFile(editing))
+	tmp.Close()
+	if err != nil {
+		return err
+	}
+
+	var edited []alias.Alias
+	for {
+		fmt.Printf("%s%s%s\n", ui.Color.Cyan, fmt.Sprintf(ui.Msg.EditOpeningEditor, ui.Editor()), ui.Color.Reset)
+		if err := ui.OpenEditor(tmp.Name()); err != nil {
+			return fmt.Errorf(ui.Msg.EditEditorFailed, err)
+		}
+		data, err := os.ReadFile(tmp.Name())
+		if err != nil {
+			return err
+		}
+		if edited, err = alias.ParseEditFile(string(data)); err == nil {
+			err = qa.validateEdited(edited)
+		}
+		if err == nil {
+			break
+		}
+
+		if !ui.CanPrompt() {
+			return err
+		}
+		fmt.Printf("%s❌ %v%s\n", ui.Color.Red, err, ui.Color.Reset)
+		if ui.Confirm(ui.Msg.EditReopenPrompt) != nil {
+			return err
+		}
+	}
+
+	result := qa.mergeEdited(name, edited)
+	changes := alias.Diff(qa.UserAliases, result)
+	if len(changes) == 0 {
+		fmt.Printf("%s%s%s\n", ui.Color.Green, ui.Msg.EditNoChanges, ui.Color.Reset)
+		return nil
+	}
+	alias.PrintChanges(changes)
+	for _, c := range changes {
+		if c.New != nil {
+			alias.PrintCommandWarnings(c.Name, alias.CheckCommand(c.New.Command))
+		}
+	}
+
+	if err := ui.Confirm(ui.Msg.EditSaveConfirmation); err != nil {
+		if errors.Is(err, ui.ErrCancelled) {
+			fmt.Printf("%s❌ %s%s\n", ui.Color.Red, ui.Msg.OperationCancelled, ui.Color.Reset)
+		}
+		return err
+	}
+
+	qa.PersistManager.CreateBackup("user", ui.Msg.ErrorProcessingBackupData, ui.Msg.ErrorWritingBackupFile)
+	qa.UserAliases = result
+	if err := qa.SaveAliases("user"); err != nil {
+		return err
+	}
+	fmt.Printf("%s✅ %s%s\n", ui.Color.Green, fmt.Sprintf(ui.Msg.EditSaved, len(changes)), ui.Color.Reset)
+	return nil
+}
+
+// validateEdited checks the names in an edited file and refuses builtin shadowing
+// unless the alias is marked with allow-shadow.
+func (qa *QuickAlias) validateEdited(edited []alias.Alias) error {
+	errs := alias.ValidateAliases(edited, qa.Config.ShellType)
+	for _, a := range edited {
+		a.Level = "user"
+		if alias.HighestSeverity(alias.ShadowedBy(a, qa.Config.ShellType)) == alias.SeverityError {
+			errs = append(errs, fmt.Errorf(ui.Msg.EditShadowBlocked, a.Name))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// mergeEdited builds the new user store from the edited aliases. When a single alias
+// was edited, the result takes its place and the rest of the store is kept.
+// Unchanged aliases keep their provenance; changed ones are stamped as updated.
+func (qa *QuickAlias) mergeEdited(name string, edited []alias.Alias) []alias.Alias {
+	now := time.Now()
+	for i := range edited {
+		edited[i].Level = "user"
+		previous, ok := alias.FindAlias(edited[i].Name, qa.UserAliases)
+		switch {
+		case ok && previous.SameDefinition(edited[i]):
+			edited[i] = previous
+		case ok:
+			alias.Stamp(&edited[i], &previous, now)
+		default:
+			alias.Stamp(&edited[i], nil, now)
+		}
+	}
+	if name == "" {
+		return edited
+	}
+
+	isEdited := func(a alias.Alias) bool {
+		return slices.ContainsFunc(edited, func(e alias.Alias) bool { return e.Name == a.Name })
+	}
+	var result []alias.Alias
+	inserted := false
+	for _, a := range qa.UserAliases {
+		if a.Name == name && !inserted {
+			result = append(result, edited...)
+			inserted = true
+			continue
+		}
+		if a.Name != name && !isEdited(a) {
+			result = append(result, a)
+		}
+	}
+	if !inserted {
+		result = append(result, edited...)
+	}
+	return result
+}
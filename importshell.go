@@ -0,0 +1,260 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"quickalias/internal/alias"
+	"quickalias/internal/shell"
+	"quickalias/internal/ui"
+)
+
+// Outcomes of a definition found by `qq import-shell`.
+const (
+	importNew       = "new"       // Imported as a new user alias.
+	importOverwrite = "overwrite" // Replaces a different qq alias (--overwrite).
+	importManaged   = "managed"   // qq already has the same alias.
+	importConflict  = "conflict"  // qq has a different alias of that name; skipped.
+	importSkipped   = "skipped"   // Cannot be imported; Reason says why.
+)
+
+// importCandidate is a definition found in a shell file and what importing it would do.
+type importCandidate struct {
+	def       shell.Definition
+	outcome   string
+	reason    string       // Why the definition is skipped.
+	redefined bool         // A later line of the file defines the same name again.
+	existing  *alias.Alias // The qq alias of the same name, if any.
+}
+
+// shownCommand returns the command for previews, with line breaks kept on one line.
+func (c importCandidate) shownCommand() string {
+	return strings.ReplaceAll(c.def.Command, "\n", `\n`)
+}
+
+// ImportShell imports alias definitions from a shell configuration file into the user level.
+// Without a path the configured shell's rc file is read. Definitions that clash with existing
+// aliases are only imported with overwrite; with commentOut the migrated lines are commented
+// out in the source file, which is backed up first.
+func (qa *QuickAlias) ImportShell(path string, overwrite, commentOut bool) error {
+	fish := strings.HasSuffix(path, ".fish")
+	if path == "" {
+		configFile, _, err := shell.ConfigFile(qa.Config.ShellType)
+		if err != nil {
+			return err
+		}
+		path, fish = configFile, qa.Config.ShellType == "fish"
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf(ui.Msg.ImportFileReadError, err)
+	}
+
+	candidates := qa.importCandidates(shell.ParseDefinitions(string(data), fish), overwrite)
+	if len(candidates) == 0 {
+		fmt.Printf("%s%s%s\n", ui.Color.Yellow, fmt.Sprintf(ui.Msg.ImportShellNothingFound, path), ui.Color.Reset)
+		return nil
+	}
+
+	fmt.Printf("%s%s%s\n", ui.Color.Cyan+ui.Color.Bold, fmt.Sprintf(ui.Msg.ImportShellFound, len(candidates), path), ui.Color.Reset)
+	var toImport []importCandidate
+	for _, c := range candidates {
+		printImportCandidate(c)
+		if c.outcome == importNew || c.outcome == importOverwrite {
+			toImport = append(toImport, c)
+		}
+	}
+	fmt.Println()
+
+	var migratedLines map[int]bool
+	if commentOut {
+		migratedLines = migratedLineSet(candidates)
+	}
+	if len(toImport) == 0 && len(migratedLines) == 0 {
+		fmt.Printf("%s%s%s\n", ui.Color.Green, ui.Msg.ImportShellNothingToDo, ui.Color.Reset)
+		return nil
+	}
+
+	prompt := fmt.Sprintf(ui.Msg.ImportShellConfirmation, len(toImport))
+	if len(migratedLines) > 0 {
+		prompt = fmt.Sprintf(ui.Msg.ImportShellConfirmationCommentOut, len(toImport), len(migratedLines), path)
+	}
+	if err := ui.Confirm(fmt.Sprintf("%s%s%s", ui.Color.Yellow, prompt, ui.Color.Reset)); err != nil {
+		if errors.Is(err, ui.ErrCancelled) {
+			fmt.Printf("%s❌ %s%s\n", ui.Color.Red, ui.Msg.OperationCancelled, ui.Color.Reset)
+		}
+		return err
+	}
+
+	if len(toImport) > 0 {
+		if err := qa.addImported(toImport); err != nil {
+			return err
+		}
+		fmt.Printf("%s✅ %s%s\n", ui.Color.Green, fmt.Sprintf(ui.Msg.ImportShellImported, len(toImport)), ui.Color.Reset)
+	}
+
+	if len(migratedLines) > 0 {
+		backup, err := commentOutLines(path, data, migratedLines)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s✅ %s%s\n", ui.Color.Green, fmt.Sprintf(ui.Msg.ImportShellCommentedOut, len(migratedLines), path, backup), ui.Color.Reset)
+	}
+
+	fmt.Printf("\n%s💡 %s %s%s%s\n", ui.Color.Cyan, ui.Msg.RestartTerminalHint, ui.Color.Bold, ui.Msg.RestartTerminalCmdHint, ui.Color.Reset)
+	return nil
+}
+
+// importCandidates decides what to do with every definition. When a name is defined
+// more than once, the shell uses the last definition, so earlier ones are skipped.
+// Secret values in the definitions, which qq init fills into the running shell, are put
+// back as placeholders first, so they are neither shown nor stored. pass and secret-tool
+// are not asked, since they may prompt: the values come from the secret file and from the
+// user aliases whose placeholders the definitions fill in. Definitions holding a secret
+// too short to put back are skipped.
+func (qa *QuickAlias) importCandidates(defs []shell.Definition, overwrite bool) []importCandidate {
+	lastLine := make(map[string]int)
+	resolver := alias.NewSecretResolver(qa.PersistManager.SecretsPath())
+	learnt := make(map[int]bool) // Definitions that are a user alias with its secrets filled in.
+	for i, d := range defs {
+		lastLine[d.Name] = d.Line
+		if a, ok := alias.FindAlias(d.Name, qa.UserAliases); ok && len(alias.SecretNames(a.Command)) > 0 {
+			learnt[i] = resolver.Learn(a.Command, d.Command)
+		}
+	}
+
+	candidates := make([]importCandidate, 0, len(defs))
+	for i, d := range defs {
+		existing, _ := alias.GetAlias(d.Name, qa.UserAliases, qa.GlobalAliases)
+		if learnt[i] {
+			d.Command = existing.Command
+		} else {
+			d.Command = resolver.Conceal(d.Command)
+		}
+		shortSecret, hasShortSecret := resolver.ShortSecret(d.Command)
+		if hasShortSecret {
+			d.Command = "" // Not shown, since it holds the secret.
+		}
+		c := importCandidate{def: d, outcome: importNew, existing: existing}
+
+		switch {
+		case hasShortSecret:
+			c.outcome, c.reason = importSkipped, fmt.Sprintf(ui.Msg.ImportShellSkipShortSecret, shortSecret)
+		case d.Kind == shell.DefGlobalAlias:
+			c.outcome, c.reason = importSkipped, ui.Msg.ImportShellSkipGlobal
+		case d.Kind == shell.DefSuffixAlias:
+			c.outcome, c.reason = importSkipped, ui.Msg.ImportShellSkipSuffix
+		case strings.Contains(d.Command, "\n"):
+			c.outcome, c.reason = importSkipped, ui.Msg.ImportShellSkipMultiline
+		case lastLine[d.Name] != d.Line:
+			c.outcome, c.reason, c.redefined = importSkipped, fmt.Sprintf(ui.Msg.ImportShellSkipRedefined, lastLine[d.Name]), true
+		case existing != nil && existing.Command == d.Command:
+			c.outcome = importManaged
+		case existing != nil && overwrite:
+			c.outcome = importOverwrite
+		case existing != nil:
+			c.outcome = importConflict
+		}
+		if c.outcome == importNew || c.outcome == importOverwrite {
+			if err := alias.ValidateName(d.Name, qa.Config.ShellType); err != nil {
+				c.outcome, c.reason = importSkipped, err.Error()
+			}
+		}
+		candidates = append(candidates, c)
+	}
+	return candidates
+}
+
+// addImported saves the chosen definitions as user aliases, after a backup, and warns about
+// commands that look like they hold a secret. Metadata of a user alias that is replaced is kept.
+func (qa *QuickAlias) addImported(candidates []importCandidate) error {
+	qa.PersistManager.CreateBackup("user", ui.Msg.ErrorProcessingBackupData, ui.Msg.ErrorWritingBackupFile)
+	now := time.Now()
+	for _, c := range candidates {
+		a := alias.Alias{Name: c.def.Name, Command: c.def.Command, Level: "user"}
+		alias.PrintCommandWarnings(a.Name, alias.CheckCommand(a.Command))
+		if previous, ok := alias.FindAlias(a.Name, qa.UserAliases); ok {
+			a.Description, a.Tags, a.Category = previous.Description, previous.Tags, previous.Category
+			alias.Stamp(&a, &previous, now)
+		} else {
+			alias.Stamp(&a, nil, now)
+		}
+		qa.UserAliases = append(alias.RemoveAlias(a.Name, qa.UserAliases), a)
+	}
+	return qa.SaveAliases("user")
+}
+
+// printImportCandidate prints one line of the import preview.
+func printImportCandidate(c importCandidate) {
+	line := fmt.Sprintf("%s → %s  %s", c.def.Name, c.shownCommand(), fmt.Sprintf(ui.Msg.ImportShellLine, c.def.Line))
+	switch c.outcome {
+	case importNew:
+		fmt.Printf("  %s+ %s%s\n", ui.Color.Green, line, ui.Color.Reset)
+	case importOverwrite:
+		fmt.Printf("  %s~ %s  %s%s\n", ui.Color.Yellow, line, fmt.Sprintf(ui.Msg.ImportShellOverwrites, c.existing.Level, c.existing.Command), ui.Color.Reset)
+	case importManaged:
+		fmt.Printf("  %s= %s  %s%s\n", ui.Color.White, line, fmt.Sprintf(ui.Msg.ImportShellManaged, c.existing.Level), ui.Color.Reset)
+	case importConflict:
+		fmt.Printf("  %s! %s  %s%s\n", ui.Color.Red, line, fmt.Sprintf(ui.Msg.ImportShellConflict, c.existing.Level, c.existing.Command), ui.Color.Reset)
+	case importSkipped:
+		fmt.Printf("  %s- %s  (%s)%s\n", ui.Color.Dim, line, c.reason, ui.Color.Reset)
+	}
+}
+
+// migratedLineSet returns the source lines that can be commented out: lines holding only
+// alias definitions, all of which are now managed by qq after the import. A definition
+// overridden later in the file counts as migrated when its last definition is.
+func migratedLineSet(candidates []importCandidate) map[int]bool {
+	managed := make(map[string]bool) // By name, for the last definition of each name.
+	for _, c := range candidates {
+		if !c.redefined {
+			managed[c.def.Name] = c.outcome == importNew || c.outcome == importOverwrite || c.outcome == importManaged
+		}
+	}
+
+	migrated := make(map[int]bool) // By first line of the definition.
+	for _, c := range candidates {
+		done := managed[c.def.Name] && c.def.Only && c.def.Supported
+		if ok, seen := migrated[c.def.Line]; seen {
+			done = done && ok
+		}
+		migrated[c.def.Line] = done
+	}
+
+	lines := make(map[int]bool)
+	for _, c := range candidates {
+		if migrated[c.def.Line] {
+			for n := c.def.Line; n <= c.def.EndLine; n++ {
+				lines[n] = true
+			}
+		}
+	}
+	return lines
+}
+
+// commentOutLines rewrites path with the given lines commented out, after saving the
+// original content next to it. It returns the path of that backup.
+func commentOutLines(path string, data []byte, lines map[int]bool) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	backup := path + ".qq-backup"
+	if err := os.WriteFile(backup, data, info.Mode().Perm()); err != nil {
+		return "", fmt.Errorf(ui.Msg.ErrorWritingBackupFile, err)
+	}
+
+	source := strings.Split(string(data), "\n")
+	for i := range source {
+		if lines[i+1] {
+			source[i] = "# " + ui.Msg.ImportShellMigratedMarker + " " + source[i]
+		}
+	}
+	if err := os.WriteFile(path, []byte(strings.Join(source, "\n")), info.Mode().Perm()); err != nil {
+		return "", err
+	}
+	return backup, nil
+}
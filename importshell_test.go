@@ -0,0 +1,105 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"quickalias/internal/alias"
+	"quickalias/internal/shell"
+)
+
+func TestImportShellCommentsOutMigratedLines(t *testing.T) {
+	qa := newTestQuickAlias(t)
+	rc := filepath.Join(t.TempDir(), ".bashrc")
+	source := strings.Join([]string{
+		"export X=1",
+		"alias ll='ls -la'",
+		"alias gs='git status'; echo hi",
+		"alias -g G='| grep'",
+		"alias up='cd ..' \\",
+		"  dn='cd -'",
+		"alias ll='ls -l'",
+		"",
+	}, "\n")
+	if err := os.WriteFile(rc, []byte(source), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := qa.ImportShell(rc, false, true); err != nil {
+		t.Fatalf("ImportShell: %v", err)
+	}
+
+	want := map[string]string{"ll": "ls -l", "gs": "git status", "up": "cd ..", "dn": "cd -"}
+	if len(qa.UserAliases) != len(want) {
+		t.Errorf("imported %+v", qa.UserAliases)
+	}
+	for _, a := range qa.UserAliases {
+		if want[a.Name] != a.Command {
+			t.Errorf("%s = %q, want %q", a.Name, a.Command, want[a.Name])
+		}
+	}
+
+	data, _ := os.ReadFile(rc)
+	for i, line := range strings.Split(string(data), "\n") {
+		commented := strings.HasPrefix(line, "# ")
+		if wantCommented := i+1 == 2 || i+1 == 5 || i+1 == 6 || i+1 == 7; commented != wantCommented {
+			t.Errorf("line %d %q: commented out = %v, want %v", i+1, line, commented, wantCommented)
+		}
+	}
+	if backup, _ := os.ReadFile(rc + ".qq-backup"); string(backup) != source {
+		t.Errorf("backup = %q", backup)
+	}
+}
+
+func TestImportCandidatesSecrets(t *testing.T) {
+	qa := newTestQuickAlias(t)
+	// A pass that records being run: candidates must not ask password managers, which may prompt.
+	bin := t.TempDir()
+	ran := filepath.Join(bin, "ran")
+	for _, helper := range []string{"pass", "secret-tool"} {
+		script := "#!/bin/sh\ntouch " + ran + "\necho from-" + helper + "\n"
+		if err := os.WriteFile(filepath.Join(bin, helper), []byte(script), 0700); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	if _, err := qa.PersistManager.SetSecret("gh", ptr("ghp_from_the_file")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := qa.PersistManager.SetSecret("pin", ptr("1234")); err != nil {
+		t.Fatal(err)
+	}
+	qa.UserAliases = []alias.Alias{
+		{Name: "api", Command: "curl -H 'Authorization: Bearer ${secret:token}' https://api", Level: "user"},
+		{Name: "gh", Command: "gh auth ${secret:gh}", Level: "user"},
+	}
+
+	defs := []shell.Definition{
+		{Name: "api", Command: "curl -H 'Authorization: Bearer t0ken-from-pass' https://api", Line: 1},
+		{Name: "gh", Command: "gh auth ghp_from_the_file", Line: 2},
+		{Name: "api2", Command: "wget --header 'Authorization: Bearer t0ken-from-pass' https://api", Line: 3},
+		{Name: "unlock", Command: "unlock --pin 1234", Line: 4},
+		{Name: "ll", Command: "ls -l", Line: 5},
+	}
+	want := map[string]struct{ outcome, command string }{
+		"api":    {importManaged, "curl -H 'Authorization: Bearer ${secret:token}' https://api"},
+		"gh":     {importManaged, "gh auth ${secret:gh}"},
+		"api2":   {importNew, "wget --header 'Authorization: Bearer ${secret:token}' https://api"},
+		"unlock": {importSkipped, ""},
+		"ll":     {importNew, "ls -l"},
+	}
+	for _, c := range qa.importCandidates(defs, false) {
+		if w := want[c.def.Name]; c.outcome != w.outcome || c.def.Command != w.command {
+			t.Errorf("%s: %s %q, want %s %q (%s)", c.def.Name, c.outcome, c.def.Command, w.outcome, w.command, c.reason)
+		}
+	}
+	if _, err := os.Stat(ran); err == nil {
+		t.Error("a password manager was run")
+	}
+}
+
+func ptr(s string) *string {
+	return &s
+}
@@ -16,6 +16,7 @@ GLOBAL_CONFIG_DIR="/etc/quickalias"
 SYSTEMD_SERVICE_DIR="/etc/systemd/system"
 COMPLETION_DIR="/usr/share/bash-completion/completions"
 ZSH_COMPLETION_DIR="/usr/share/zsh/site-functions"
+FISH_COMPLETION_DIR="/usr/share/fish/vendor_completions.d"
 
 # Print colored output
 print_info() {
@@ -159,85 +160,20 @@ create_global_config() {
 install_completions() {
     print_info "Installing shell completions..."
 
-    # Create bash completion
+    # Completion scripts are generated by the binary itself from its command table
     sudo mkdir -p "$COMPLETION_DIR"
-    cat << 'EOF' | sudo tee "$COMPLETION_DIR/qq" > /dev/null
-_qq_completion() {
-    local cur prev opts
-    COMPREPLY=()
-    cur="${COMP_WORDS[COMP_CWORD]}"
-    prev="${COMP_WORDS[COMP_CWORD-1]}"
-
-    case ${prev} in
-        qq)
-            opts="add set remove remove-global list search control status setup init config version help"
-            COMPREPLY=( $(compgen -W "${opts}" -- ${cur}) )
-            return 0
-            ;;
-        config)
-            opts="reset backup export import"
-            COMPREPLY=( $(compgen -W "${opts}" -- ${cur}) )
-            return 0
-            ;;
-        remove|remove-global)
-            # Complete with existing alias names
-            local aliases=$(qq list 2>/dev/null | grep -E "^\s+\w+" | awk '{print $1}' | tr -d '→')
-            COMPREPLY=( $(compgen -W "${aliases}" -- ${cur}) )
-            return 0
-            ;;
-    esac
-}
-
-complete -F _qq_completion qq
-EOF
+    "$INSTALL_DIR/$BINARY_NAME" completion bash | sudo tee "$COMPLETION_DIR/qq" > /dev/null
 
     # Create zsh completion if zsh is installed
     if command -v zsh &> /dev/null; then
         sudo mkdir -p "$ZSH_COMPLETION_DIR"
-        cat << 'EOF' | sudo tee "$ZSH_COMPLETION_DIR/_qq" > /dev/null
-#compdef qq
-
-_qq() {
-    local context state line
-
-    _arguments -C \
-        '1: :->commands' \
-        '*: :->args' \
-        && return 0
-
-    case $state in
-        commands)
-            _values 'qq commands' \
-                'add[Add user-level alias]' \
-                'set[Add global alias]' \
-                'remove[Remove user alias]' \
-                'remove-global[Remove global alias]' \
-                'list[List all aliases]' \
-                'search[Search aliases]' \
-                'control[Show status]' \
-                'status[Show status]' \
-                'setup[Setup shell integration]' \
-                'init[Initialize aliases]' \
-                'config[Configuration commands]' \
-                'version[Show version]' \
-                'help[Show help]'
-            ;;
-        args)
-            case $words[2] in
-                config)
-                    _values 'config commands' \
-                        'reset[Reset configuration]' \
-                        'backup[Show backups]' \
-                        'export[Export aliases]' \
-                        'import[Import aliases]'
-                    ;;
-            esac
-            ;;
-    esac
-}
+        "$INSTALL_DIR/$BINARY_NAME" completion zsh | sudo tee "$ZSH_COMPLETION_DIR/_qq" > /dev/null
+    fi
 
-_qq "$@"
-EOF
+    # Create fish completion if fish is installed
+    if command -v fish &> /dev/null; then
+        sudo mkdir -p "$FISH_COMPLETION_DIR"
+        "$INSTALL_DIR/$BINARY_NAME" completion fish | sudo tee "$FISH_COMPLETION_DIR/qq.fish" > /dev/null
     fi
 
     print_success "Shell completions installed"
@@ -269,8 +205,8 @@ Add a global alias (requires sudo)
 .B remove \fIalias\fR
 Remove a user alias
 .TP
-.B remove-global \fIalias\fR
-Remove a global alias
+.B unset \fIalias\fR
+Remove a global alias (requires sudo)
 .TP
 .B list [\fIkeyword\fR]
 List all aliases or filter by keyword
@@ -290,6 +226,9 @@ Initialize aliases (used by shell)
 .B config \fIsubcommand\fR
 Configuration management
 .TP
+.B completion \fIbash\fR|\fIzsh\fR|\fIfish\fR
+Print a shell completion script
+.TP
 .B version
 Show version information
 .TP
@@ -372,7 +311,7 @@ post_install() {
     echo "  4. View help: qq help"
     echo "  5. Check man page: man qq"
     echo
-    print_info "Shell completions are available for bash and zsh"
+    print_info "Shell completions are available for bash, zsh and fish"
     print_info "Global aliases require sudo: sudo qq set <alias> \"<command>\""
 }
 
@@ -389,6 +328,7 @@ uninstall() {
     # Remove completions
     sudo rm -f "$COMPLETION_DIR/qq"
     sudo rm -f "$ZSH_COMPLETION_DIR/_qq"
+    sudo rm -f "$FISH_COMPLETION_DIR/qq.fish"
     print_success "Completions removed"
 
     # Remove man page
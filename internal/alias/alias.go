@@ -26,6 +26,16 @@ func GetAlias(name string, userAliases, globalAliases []Alias) (*Alias, string)
 	return nil, ""
 }
 
+// FindAlias returns the alias with the given name from a single level.
+func FindAlias(name string, aliases []Alias) (Alias, bool) {
+	for _, a := range aliases {
+		if a.Name == name {
+			return a, true
+		}
+	}
+	return Alias{}, false
+}
+
 // RemoveAlias is a helper function to remove an alias from a slice of aliases.
 func RemoveAlias(name string, aliases []Alias) []Alias {
 	for i, a := range aliases {
@@ -36,96 +46,280 @@ func RemoveAlias(name string, aliases []Alias) []Alias {
 	return aliases // If not found, return the original slice.
 }
 
-// ListAliases prints all user and global aliases, optionally filtered by a keyword.
-func ListAliases(userAliases, globalAliases []Alias, keyword, globalHeader, userHeader, noGlobalMsg, noUserMsg, totalFoundMsg, colorPurpleBold, colorBlueBold, colorGreen, colorReset, colorYellow, colorCyan string) {
-	fmt.Printf("%s%s%s\n", colorPurpleBold, globalHeader, colorReset)
-	globalCount := 0
-	for _, a := range globalAliases {
-		if keyword == "" || strings.Contains(a.Name, keyword) || strings.Contains(a.Command, keyword) {
-			fmt.Printf("  %s%s%s  → %s%s%s\n", colorGreen+ui.ColorBold, a.Name, colorReset, colorCyan, a.Command, colorReset) // ui.ColorBold kullanıldı
-			globalCount++
-		}
-	}
+// ListAliases prints all user, pack and global aliases that pass the filter, grouped by category.
+// Aliases whose conditions do not hold on m, and pack aliases overridden by a user alias,
+// are marked with the reason. The pack section is only shown when packs are installed.
+func ListAliases(userAliases, packAliases, globalAliases []Alias, filter Filter, m Machine) {
+	fmt.Printf("%s%s%s\n", ui.Color.Purple+ui.Color.Bold, ui.Msg.GlobalAliasesHeader, ui.Color.Reset)
+	globalMatches := FilterAliases(globalAliases, filter)
+	globalCount := len(globalMatches)
+	printAliasGroups(globalMatches, m)
 	if globalCount == 0 {
-		fmt.Printf("  %s%s%s\n", colorYellow, noGlobalMsg, colorReset)
+		fmt.Printf("  %s%s%s\n", ui.Color.Yellow, ui.Msg.NoGlobalAliases, ui.Color.Reset)
 	}
 
-	fmt.Printf("\n%s%s%s\n", colorBlueBold, userHeader, colorReset)
-	userCount := 0
-	for _, a := range userAliases {
-		if keyword == "" || strings.Contains(a.Name, keyword) || strings.Contains(a.Command, keyword) {
-			fmt.Printf("  %s%s%s  → %s%s%s\n", colorGreen+ui.ColorBold, a.Name, colorReset, colorCyan, a.Command, colorReset) // ui.ColorBold kullanıldı
-			userCount++
+	packMatches := FilterAliases(packAliases, filter)
+	if len(packAliases) > 0 {
+		fmt.Printf("\n%s%s%s\n", ui.Color.Cyan+ui.Color.Bold, ui.Msg.PackAliasesHeader, ui.Color.Reset)
+		printPackAliases(packMatches, userAliases, m)
+		if len(packMatches) == 0 {
+			fmt.Printf("  %s%s%s\n", ui.Color.Yellow, ui.Msg.NoPackAliasesMatch, ui.Color.Reset)
 		}
 	}
+
+	fmt.Printf("\n%s%s%s\n", ui.Color.Blue+ui.Color.Bold, ui.Msg.UserAliasesHeader, ui.Color.Reset)
+	userMatches := FilterAliases(userAliases, filter)
+	userCount := len(userMatches)
+	printAliasGroups(userMatches, m)
 	if userCount == 0 {
-		fmt.Printf("  %s%s%s\n", colorYellow, noUserMsg, colorReset)
+		fmt.Printf("  %s%s%s\n", ui.Color.Yellow, ui.Msg.NoUserAliases, ui.Color.Reset)
 	}
 
-	if keyword != "" {
-		fmt.Printf("\n%s%s%s\n", colorGreen, fmt.Sprintf(totalFoundMsg, globalCount+userCount), colorReset)
+	if filter.Active() {
+		fmt.Printf("\n%s%s%s\n", ui.Color.Green, fmt.Sprintf(ui.Msg.TotalAliasesFound, globalCount+len(packMatches)+userCount), ui.Color.Reset)
 	}
 }
 
 // SearchAliases searches for aliases containing the given keyword in their name or command.
-func SearchAliases(userAliases, globalAliases []Alias, keyword, searchResultsMsg, globalHeader, userHeader, noResultsMsg, totalResultsMsg, colorCyanBold, colorRed, colorGreen, colorReset, colorPurple, colorBlue, colorCyan string) {
-	fmt.Printf("%s%s: '%s'%s\n", colorCyanBold, searchResultsMsg, keyword, colorReset)
+func SearchAliases(userAliases, packAliases, globalAliases []Alias, keyword string, m Machine) {
+	fmt.Printf("%s%s: '%s'%s\n", ui.Color.Cyan+ui.Color.Bold, ui.Msg.SearchResults, keyword, ui.Color.Reset)
 
-	fmt.Printf("%s%s%s\n", colorPurple, globalHeader, colorReset)
-	globalCount := 0
-	for _, a := range globalAliases {
-		if strings.Contains(a.Name, keyword) || strings.Contains(a.Command, keyword) {
-			fmt.Printf("  %s%s%s  → %s%s%s\n", colorGreen+ui.ColorBold, a.Name, colorReset, colorCyan, a.Command, colorReset) // ui.ColorBold kullanıldı
-			globalCount++
+	fmt.Printf("%s%s%s\n", ui.Color.Purple, ui.Msg.GlobalAliasesHeader, ui.Color.Reset)
+	globalMatches := FilterAliases(globalAliases, Filter{Keyword: keyword})
+	for _, a := range globalMatches {
+		printAliasLine(a, a.InactiveReasons(m))
+	}
+
+	packMatches := FilterAliases(packAliases, Filter{Keyword: keyword})
+	if len(packMatches) > 0 {
+		fmt.Printf("\n%s%s%s\n", ui.Color.Cyan, ui.Msg.PackAliasesHeader, ui.Color.Reset)
+		printPackAliases(packMatches, userAliases, m)
+	}
+
+	fmt.Printf("\n%s%s%s\n", ui.Color.Blue, ui.Msg.UserAliasesHeader, ui.Color.Reset)
+	userMatches := FilterAliases(userAliases, Filter{Keyword: keyword})
+	for _, a := range userMatches {
+		printAliasLine(a, a.InactiveReasons(m))
+	}
+
+	totalFound := len(globalMatches) + len(packMatches) + len(userMatches)
+	if totalFound == 0 {
+		fmt.Printf("\n%s❌ %s%s\n", ui.Color.Red, fmt.Sprintf(ui.Msg.NoResultsFound, keyword), ui.Color.Reset)
+	} else {
+		fmt.Printf("\n%s✅ %s%s\n", ui.Color.Green, fmt.Sprintf(ui.Msg.TotalResultsFound, totalFound), ui.Color.Reset)
+	}
+}
+
+// printPackAliases prints pack aliases under a sub-heading per pack. Aliases that a user
+// alias of the same name overrides are marked as inactive.
+func printPackAliases(aliases, userAliases []Alias, m Machine) {
+	pack := ""
+	for _, a := range aliases {
+		if a.PackName() != pack {
+			pack = a.PackName()
+			fmt.Printf("  %s▸ %s%s\n", ui.Color.Cyan, pack, ui.Color.Reset)
 		}
+		reasons := a.InactiveReasons(m)
+		if _, ok := FindAlias(a.Name, userAliases); ok {
+			reasons = append(reasons, ui.Msg.PackAliasOverridden)
+		}
+		fmt.Print("  ")
+		printAliasLine(a, reasons)
 	}
+}
 
-	fmt.Printf("\n%s%s%s\n", colorBlue, userHeader, colorReset)
-	userCount := 0
-	for _, a := range userAliases {
-		if strings.Contains(a.Name, keyword) || strings.Contains(a.Command, keyword) {
-			fmt.Printf("  %s%s%s  → %s%s%s\n", colorGreen+ui.ColorBold, a.Name, colorReset, colorCyan, a.Command, colorReset) // ui.ColorBold kullanıldı
-			userCount++
+// printAliasGroups prints aliases under a sub-heading per category; uncategorized aliases come first.
+func printAliasGroups(aliases []Alias, m Machine) {
+	categories, groups := GroupByCategory(aliases)
+	for _, category := range categories {
+		indent := ""
+		if category != "" {
+			fmt.Printf("  %s▸ %s%s\n", ui.Color.Purple, category, ui.Color.Reset)
+			indent = "  "
+		}
+		for _, a := range groups[category] {
+			fmt.Print(indent)
+			printAliasLine(a, a.InactiveReasons(m))
 		}
 	}
+}
 
-	totalFound := globalCount + userCount
-	if totalFound == 0 {
-		fmt.Printf("\n%s❌ %s%s\n", colorRed, fmt.Sprintf(noResultsMsg, keyword), colorReset)
+// printAliasLine prints a single alias as "name  → command", followed by its description and tags.
+// Disabled aliases, and aliases with reasons to be inactive, are dimmed and marked.
+func printAliasLine(a Alias, inactive []string) {
+	switch {
+	case a.Disabled:
+		fmt.Printf("  %s%s  → %s  %s%s\n", ui.Color.Dim, a.Name, a.Command, ui.Msg.DisabledMarker, ui.Color.Reset)
+		return
+	case len(inactive) > 0:
+		fmt.Printf("  %s%s  → %s  %s%s\n", ui.Color.Dim, a.Name, a.Command,
+			fmt.Sprintf(ui.Msg.InactiveMarker, strings.Join(inactive, "; ")), ui.Color.Reset)
+		return
+	}
+	fmt.Printf("  %s%s%s  → %s%s%s", ui.Color.Green+ui.Color.Bold, a.Name, ui.Color.Reset, ui.Color.Cyan, a.Command, ui.Color.Reset)
+	if a.Description != "" {
+		fmt.Printf("  %s— %s%s", ui.Color.White, a.Description, ui.Color.Reset)
+	}
+	if len(a.Tags) > 0 {
+		fmt.Printf("  %s[%s]%s", ui.Color.Yellow, strings.Join(a.Tags, ", "), ui.Color.Reset)
+	}
+	fmt.Println()
+}
+
+// ShowAlias prints every detail of one alias definition, including where it came from.
+// effective marks the definition the shell actually uses when the name exists on both levels;
+// conditions that do not hold on m are explained.
+func ShowAlias(a Alias, effective bool, m Machine) {
+	fmt.Printf("%s%s%s  %s(%s)%s", ui.Color.Green+ui.Color.Bold, a.Name, ui.Color.Reset, ui.Color.Purple, a.Level, ui.Color.Reset)
+	if effective {
+		fmt.Printf("  %s%s%s", ui.Color.Cyan, ui.Msg.ShowEffectiveMarker, ui.Color.Reset)
+	}
+	fmt.Println()
+
+	field := func(label, value string) {
+		if value != "" {
+			fmt.Printf("  %s%-17s%s %s\n", ui.Color.White, label, ui.Color.Reset, value)
+		}
+	}
+	field(ui.Msg.ShowCommandLabel, ui.Color.Cyan+a.Command+ui.Color.Reset)
+	field(ui.Msg.ShowDescriptionLabel, a.Description)
+	field(ui.Msg.ShowCategoryLabel, a.Category)
+	field(ui.Msg.ShowTagsLabel, strings.Join(a.Tags, ", "))
+	field(ui.Msg.ShowCreatedLabel, a.Created)
+	field(ui.Msg.ShowUpdatedLabel, a.Updated)
+	field(ui.Msg.ShowCreatedByLabel, a.CreatedBy)
+	field(ui.Msg.ShowHostLabel, a.Host)
+	if a.AllowShadow {
+		field(ui.Msg.ShowAllowShadowLabel, ui.Msg.ShowYes)
+	}
+	if a.Disabled {
+		field(ui.Msg.ShowStateLabel, ui.Msg.StateDisabled)
+	}
+	field(ui.Msg.ShowConditionsLabel, a.Conditions.String())
+	if reasons := a.InactiveReasons(m); len(reasons) > 0 {
+		field(ui.Msg.ShowInactiveLabel, ui.Color.Yellow+strings.Join(reasons, "; ")+ui.Color.Reset)
+	}
+}
+
+// ShowResolution prints which definition of an alias wins, what it overrides and shadows,
+// and the line the shell receives from `qq init`.
+func ShowResolution(r Resolution) {
+	if r.InitLine != "" {
+		fmt.Printf("%s%s%s  → %s%s%s  %s(%s)%s\n", ui.Color.Green+ui.Color.Bold, r.Name, ui.Color.Reset,
+			ui.Color.Cyan, r.Effective.Command, ui.Color.Reset, ui.Color.Purple, r.Effective.Level, ui.Color.Reset)
 	} else {
-		fmt.Printf("\n%s✅ %s%s\n", colorGreen, fmt.Sprintf(totalResultsMsg, totalFound), colorReset)
+		fmt.Printf("%s%s%s\n", ui.Color.Green+ui.Color.Bold, r.Name, ui.Color.Reset)
+	}
+
+	if len(r.Shadowed) > 0 {
+		fmt.Printf("\n%s%s%s\n", ui.Color.Cyan+ui.Color.Bold, ui.Msg.WhichOverridesHeader, ui.Color.Reset)
+		for _, a := range r.Shadowed {
+			fmt.Printf("  %s(%s)%s", ui.Color.Purple, a.Level, ui.Color.Reset)
+			printAliasLine(a, nil)
+		}
+	}
+	if len(r.Disabled) > 0 {
+		fmt.Printf("\n%s%s%s\n", ui.Color.Cyan+ui.Color.Bold, ui.Msg.WhichDisabledHeader, ui.Color.Reset)
+		for _, a := range r.Disabled {
+			fmt.Printf("  %s(%s)%s", ui.Color.Purple, a.Level, ui.Color.Reset)
+			printAliasLine(a, nil)
+		}
+	}
+	if len(r.Inactive) > 0 {
+		fmt.Printf("\n%s%s%s\n", ui.Color.Cyan+ui.Color.Bold, ui.Msg.WhichInactiveHeader, ui.Color.Reset)
+		for _, i := range r.Inactive {
+			fmt.Printf("  %s(%s)%s", ui.Color.Purple, i.Alias.Level, ui.Color.Reset)
+			printAliasLine(i.Alias, i.Reasons)
+		}
 	}
+	if len(r.Shadows) > 0 {
+		fmt.Printf("\n%s%s%s\n", ui.Color.Cyan+ui.Color.Bold, ui.Msg.ShadowingHeader, ui.Color.Reset)
+		for _, f := range r.Shadows {
+			PrintFinding(f)
+		}
+	}
+
+	fmt.Printf("\n%s%s%s\n", ui.Color.Cyan+ui.Color.Bold, fmt.Sprintf(ui.Msg.WhichInitLineHeader, r.ShellType), ui.Color.Reset)
+	if r.InitLine == "" {
+		message := ui.Msg.WhichAllDisabled
+		if len(r.Inactive) > 0 {
+			message = ui.Msg.WhichNoneActive
+		}
+		fmt.Printf("  %s%s%s\n", ui.Color.Yellow, message, ui.Color.Reset)
+		return
+	}
+	fmt.Printf("  %s\n", r.InitLine)
 }
 
 // ShowStatus displays the current status of QuickAlias, including alias counts and conflicts.
-func ShowStatus(userAliasCount, globalAliasCount int, conflicts []string, initialized bool, quickAliasStatusMsg, userAliasesCountMsg, globalAliasesCountMsg, userGlobalConflictsMsg, conflictsHintMsg, shellIntegrationStatusMsg, statusActiveMsg, statusNotActiveMsg, conflictPrecedenceHintMsg, colorCyanBold, colorBlue, colorPurple, colorGreen, colorYellow, colorReset, colorWhite string) error {
-	fmt.Printf("%s%s%s\n", colorCyanBold, quickAliasStatusMsg, colorReset)
-	fmt.Printf(userAliasesCountMsg+"\n", colorBlue, ui.ColorBold, userAliasCount, colorReset)       // ui.ColorBold kullanıldı
-	fmt.Printf(globalAliasesCountMsg+"\n", colorPurple, ui.ColorBold, globalAliasCount, colorReset) // ui.ColorBold kullanıldı
+func ShowStatus(status Status) error {
+	fmt.Printf("%s%s%s\n", ui.Color.Cyan+ui.Color.Bold, ui.Msg.QuickAliasStatus, ui.Color.Reset)
+	fmt.Printf(ui.Msg.UserAliasesCount+"\n", ui.Color.Blue+ui.Color.Bold, ui.Color.Reset, status.UserAliases)
+	fmt.Printf(ui.Msg.PackAliasesCount+"\n", ui.Color.Cyan+ui.Color.Bold, ui.Color.Reset, status.PackAliases, status.Packs)
+	fmt.Printf(ui.Msg.GlobalAliasesCount+"\n", ui.Color.Purple+ui.Color.Bold, ui.Color.Reset, status.GlobalAliases)
 
-	conflictColor := colorGreen
-	if len(conflicts) > 0 {
-		conflictColor = colorYellow // Change color if conflicts exist.
+	conflictColor := ui.Color.Green
+	if len(status.Conflicts) > 0 {
+		conflictColor = ui.Color.Yellow // Change color if conflicts exist.
 	}
-	fmt.Printf(userGlobalConflictsMsg, conflictColor, ui.ColorBold, len(conflicts), colorReset) // ui.ColorBold kullanıldı
-	if len(conflicts) > 0 {
-		fmt.Printf(" %s%s%s", colorYellow, fmt.Sprintf(conflictsHintMsg, strings.Join(conflicts, ", ")), colorReset)
+	fmt.Printf(ui.Msg.UserGlobalConflicts, conflictColor+ui.Color.Bold, ui.Color.Reset, len(status.Conflicts))
+	if len(status.Conflicts) > 0 {
+		fmt.Printf(" %s%s%s", ui.Color.Yellow, fmt.Sprintf(ui.Msg.ConflictsHint, strings.Join(status.Conflicts, ", ")), ui.Color.Reset)
 	}
 	fmt.Println()
 
-	statusText := statusNotActiveMsg // Default status is not active.
-	if initialized {
-		statusText = statusActiveMsg // Change to active if initialized.
+	statusText := ui.Msg.StatusNotActive // Default status is not active.
+	if status.ShellIntegration {
+		statusText = ui.Msg.StatusActive // Change to active if initialized.
+	}
+	fmt.Printf(ui.Msg.ShellIntegrationStatus+"\n", ui.Color.White, ui.Color.Reset, statusText)
+
+	if len(status.Conflicts) > 0 {
+		fmt.Printf("\n%s%s%s\n", ui.Color.Cyan, ui.Msg.ConflictPrecedenceHint, ui.Color.Reset)
 	}
-	fmt.Printf(shellIntegrationStatusMsg+"\n", colorWhite, statusText, colorReset) // colorReset parametresi eklendi.
 
-	if len(conflicts) > 0 {
-		fmt.Printf("\n%s%s%s\n", ui.ColorCyan, conflictPrecedenceHintMsg, ui.ColorReset) // ui.ColorCyan ve ui.ColorReset kullanıldı
+	// Shadowing of other layers is already reported as a conflict above.
+	var shadowing []Finding
+	for _, f := range status.Findings {
+		if f.Kind != ShadowAlias {
+			shadowing = append(shadowing, f)
+		}
+	}
+	if len(shadowing) > 0 {
+		fmt.Printf("\n%s%s%s\n", ui.Color.Cyan+ui.Color.Bold, ui.Msg.ShadowingHeader, ui.Color.Reset)
+		for _, f := range shadowing {
+			PrintFinding(f)
+		}
 	}
 
 	return nil
 }
 
+// PrintFinding prints a single shadowing finding, coloured by severity.
+func PrintFinding(f Finding) {
+	icon, color := "💡", ui.Color.Cyan
+	switch f.Severity {
+	case SeverityError:
+		icon, color = "❌", ui.Color.Red
+	case SeverityWarning:
+		icon, color = "⚠️ ", ui.Color.Yellow
+	}
+
+	var text string
+	switch f.Kind {
+	case ShadowAlias:
+		text = fmt.Sprintf(ui.Msg.ShadowsAlias, f.Alias, f.Level, f.Target)
+	case ShadowBuiltin:
+		text = fmt.Sprintf(ui.Msg.ShadowsBuiltin, f.Alias, f.Level, f.Target)
+	default:
+		text = fmt.Sprintf(ui.Msg.ShadowsCommand, f.Alias, f.Level, f.Target)
+	}
+	if f.Allowed {
+		text += " " + ui.Msg.ShadowAllowedMarker
+	}
+	fmt.Printf("  %s%s [%s] %s%s\n", color, icon, f.Severity, text, ui.Color.Reset)
+}
+
 // FindConflicts identifies aliases that exist at both user and global levels.
 // User-level aliases take precedence over global ones.
 func FindConflicts(userAliases, globalAliases []Alias) []string {
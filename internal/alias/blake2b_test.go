@@ -0,0 +1,38 @@
+package alias
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestBlake2b512(t *testing.T) {
+	// RFC 7693, Appendix A.
+	if got := blake2b512([]byte("abc")); hex.EncodeToString(got[:]) != "ba80a53f981c4d0d6a2797b69f12f6e94c212f14685ac4b74b12bb6fdbffa2d1"+
+		"7d87c5392aab792dc252d5de4533cc9518d38aa8dbf1925ab92386edd4009923" {
+		t.Errorf("blake2b512(\"abc\") = %x", got)
+	}
+
+	// Lengths around the 128-byte block size, where the last block and the counter are
+	// easy to get wrong. The input is byte i = i % 251; digests from Python's hashlib.
+	tests := []struct {
+		length int
+		digest string
+	}{
+		{0, "786a02f742015903c6c6fd852552d272912f4740e15847618a86e217f71f5419d25e1031afee585313896444934eb04b903a685b1448b755d56f701afe9be2ce"},
+		{127, "b6292669ccd38d5f01caae96ba272c76a879a45743afa0725d83b9ebb26665b731f1848c52f11972b6644f554c064fa90780dbbbf3a89d4fc31f67df3e5857ef"},
+		{128, "2319e3789c47e2daa5fe807f61bec2a1a6537fa03f19ff32e87eecbfd64b7e0e8ccff439ac333b040f19b0c4ddd11a61e24ac1fe0f10a039806c5dcc0da3d115"},
+		{129, "f59711d44a031d5f97a9413c065d1e614c417ede998590325f49bad2fd444d3e4418be19aec4e11449ac1a57207898bc57d76a1bcf3566292c20c683a5c4648f"},
+		{256, "93463ac058b6163eb43be3f5bb32b28541498f4e3366f1effe253ad44e1e076e41c3616046027c82a7124f8f4746668ad10b12e8e25a95ac8f3151df01cd5a93"},
+		{257, "9ca40e2ddee9436dbbd08efc65dbaf4870059f5eb3d76efd20241ae5bf13c60f250b882ea5c564838257a3fc95c496819ace2c6490b55b268535208dfc31822c"},
+		{1000, "c11e1c0340bd7e5a1b275f1230c962fad215ecb1391486e74e31b960a2f2996381a5fad092da06841d5f26e38f6ecfeaf441acbcd1c2de61aef121e7927175f5"},
+	}
+	for _, tt := range tests {
+		data := make([]byte, tt.length)
+		for i := range data {
+			data[i] = byte(i % 251)
+		}
+		if got := blake2b512(data); hex.EncodeToString(got[:]) != tt.digest {
+			t.Errorf("blake2b512(%d bytes) = %x, want %s", tt.length, got, tt.digest)
+		}
+	}
+}
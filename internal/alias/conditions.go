@@ -0,0 +1,164 @@
+package alias
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"runtime"
+	"slices"
+	"strings"
+
+	"quickalias/internal/ui"
+)
+
+// Conditions restrict where an alias is active. Every non-empty condition must hold;
+// within one condition a single match is enough.
+type Conditions struct {
+	Hosts    []string `json:"hosts,omitempty"`    // Hostname globs, e.g. "web-*".
+	OS       []string `json:"os,omitempty"`       // Operating systems as in GOOS, e.g. "linux", "darwin".
+	Shells   []string `json:"shells,omitempty"`   // Shell types, e.g. "zsh".
+	Requires []string `json:"requires,omitempty"` // Commands that must all be on $PATH.
+	Env      []string `json:"env,omitempty"`      // "VAR" must be set and non-empty, "VAR=value" must match; all must hold.
+}
+
+// IsZero reports whether no condition is set. A nil *Conditions has none.
+func (c *Conditions) IsZero() bool {
+	return c == nil || len(c.Hosts) == 0 && len(c.OS) == 0 && len(c.Shells) == 0 && len(c.Requires) == 0 && len(c.Env) == 0
+}
+
+// Equal reports whether two sets of conditions are the same; nil equals empty.
+func (c *Conditions) Equal(other *Conditions) bool {
+	if c.IsZero() || other.IsZero() {
+		return c.IsZero() && other.IsZero()
+	}
+	return slices.Equal(c.Hosts, other.Hosts) && slices.Equal(c.OS, other.OS) && slices.Equal(c.Shells, other.Shells) &&
+		slices.Equal(c.Requires, other.Requires) && slices.Equal(c.Env, other.Env)
+}
+
+// Validate rejects malformed hostname globs.
+func (c *Conditions) Validate() error {
+	if c == nil {
+		return nil
+	}
+	for _, pattern := range c.Hosts {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf(ui.Msg.InvalidHostPattern, pattern)
+		}
+	}
+	return nil
+}
+
+// SplitList splits comma-separated values, trimming them and dropping empty entries.
+func SplitList(values ...string) []string {
+	var list []string
+	for _, value := range values {
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+	}
+	return list
+}
+
+// fields returns the key and values of every condition, in display order.
+// The keys are used by the edit file format and String.
+func (c *Conditions) fields() []struct {
+	key    string
+	values *[]string
+} {
+	return []struct {
+		key    string
+		values *[]string
+	}{
+		{"hosts", &c.Hosts},
+		{"os", &c.OS},
+		{"shells", &c.Shells},
+		{"requires", &c.Requires},
+		{"env", &c.Env},
+	}
+}
+
+// String renders the conditions as "hosts=web-*; requires=kubectl".
+func (c *Conditions) String() string {
+	if c.IsZero() {
+		return ""
+	}
+	var parts []string
+	for _, f := range c.fields() {
+		if len(*f.values) > 0 {
+			parts = append(parts, f.key+"="+strings.Join(*f.values, ","))
+		}
+	}
+	return strings.Join(parts, "; ")
+}
+
+// Machine describes where aliases are being emitted, for evaluating conditions.
+type Machine struct {
+	Hostname  string
+	OS        string
+	Shell     string
+	LookPath  func(file string) (string, error)
+	LookupEnv func(key string) (string, bool)
+}
+
+// CurrentMachine describes this machine for the given shell type.
+func CurrentMachine(shellType string) Machine {
+	hostname, _ := os.Hostname()
+	return Machine{
+		Hostname:  hostname,
+		OS:        runtime.GOOS,
+		Shell:     shellType,
+		LookPath:  exec.LookPath,
+		LookupEnv: os.LookupEnv,
+	}
+}
+
+// InactiveReasons explains why the alias's conditions do not hold on m.
+// An empty result means the alias is active.
+func (a Alias) InactiveReasons(m Machine) []string {
+	if a.Conditions.IsZero() {
+		return nil
+	}
+	c := a.Conditions
+	var reasons []string
+	if len(c.Hosts) > 0 && !slices.ContainsFunc(c.Hosts, func(p string) bool { return hostMatches(p, m.Hostname) }) {
+		reasons = append(reasons, fmt.Sprintf(ui.Msg.ReasonHost, m.Hostname, strings.Join(c.Hosts, ", ")))
+	}
+	if len(c.OS) > 0 && !slices.Contains(c.OS, m.OS) {
+		reasons = append(reasons, fmt.Sprintf(ui.Msg.ReasonOS, m.OS, strings.Join(c.OS, ", ")))
+	}
+	if len(c.Shells) > 0 && !slices.Contains(c.Shells, m.Shell) {
+		reasons = append(reasons, fmt.Sprintf(ui.Msg.ReasonShell, m.Shell, strings.Join(c.Shells, ", ")))
+	}
+	for _, command := range c.Requires {
+		if _, err := m.LookPath(command); err != nil {
+			reasons = append(reasons, fmt.Sprintf(ui.Msg.ReasonCommand, command))
+		}
+	}
+	for _, env := range c.Env {
+		key, want, hasValue := strings.Cut(env, "=")
+		value, ok := m.LookupEnv(key)
+		switch {
+		case hasValue && value != want:
+			reasons = append(reasons, fmt.Sprintf(ui.Msg.ReasonEnvValue, key, want))
+		case !hasValue && (!ok || value == ""):
+			reasons = append(reasons, fmt.Sprintf(ui.Msg.ReasonEnvUnset, key))
+		}
+	}
+	return reasons
+}
+
+// Active reports whether the alias is enabled and its conditions hold on m.
+func (a Alias) Active(m Machine) bool {
+	return !a.Disabled && len(a.InactiveReasons(m)) == 0
+}
+
+// hostMatches matches a hostname glob against the full hostname or its first label.
+func hostMatches(pattern, hostname string) bool {
+	short, _, _ := strings.Cut(hostname, ".")
+	full, _ := path.Match(pattern, hostname)
+	first, _ := path.Match(pattern, short)
+	return full || first
+}
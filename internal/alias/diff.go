@@ -0,0 +1,80 @@
+package alias
+
+import (
+	"fmt"
+	"slices"
+
+	"quickalias/internal/ui"
+)
+
+// Kinds of change between two sets of aliases.
+const (
+	ChangeAdd    = "add"
+	ChangeUpdate = "change"
+	ChangeRemove = "remove"
+)
+
+// Change is one difference between two sets of aliases.
+type Change struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+	Old  *Alias `json:"old,omitempty"`
+	New  *Alias `json:"new,omitempty"`
+}
+
+// SameDefinition reports whether two aliases define the same thing, ignoring provenance.
+func (a Alias) SameDefinition(b Alias) bool {
+	return a.Name == b.Name && a.Command == b.Command && a.Description == b.Description &&
+		a.Category == b.Category && slices.Equal(a.Tags, b.Tags) && a.AllowShadow == b.AllowShadow &&
+		a.Disabled == b.Disabled && a.Conditions.Equal(b.Conditions)
+}
+
+// Diff lists the changes that turn oldAliases into newAliases: additions and
+// changes in the order of newAliases, followed by removals.
+func Diff(oldAliases, newAliases []Alias) []Change {
+	var changes []Change
+	for i := range newAliases {
+		n := newAliases[i]
+		o, ok := FindAlias(n.Name, oldAliases)
+		switch {
+		case !ok:
+			changes = append(changes, Change{Kind: ChangeAdd, Name: n.Name, New: &n})
+		case !o.SameDefinition(n):
+			changes = append(changes, Change{Kind: ChangeUpdate, Name: n.Name, Old: &o, New: &n})
+		}
+	}
+	for i := range oldAliases {
+		o := oldAliases[i]
+		if _, ok := FindAlias(o.Name, newAliases); !ok {
+			changes = append(changes, Change{Kind: ChangeRemove, Name: o.Name, Old: &o})
+		}
+	}
+	return changes
+}
+
+// PrintChanges prints changes as a diff: + for additions, ~ for changes and - for removals.
+func PrintChanges(changes []Change) {
+	for _, c := range changes {
+		switch c.Kind {
+		case ChangeAdd:
+			fmt.Printf("%s+ %s → %s%s\n", ui.Color.Green, c.Name, c.New.Command, ui.Color.Reset)
+		case ChangeUpdate:
+			fmt.Printf("%s~ %s%s\n", ui.Color.Yellow, c.Name, ui.Color.Reset)
+			if c.Old.Command != c.New.Command {
+				fmt.Printf("%s    - %s%s\n", ui.Color.Red, c.Old.Command, ui.Color.Reset)
+				fmt.Printf("%s    + %s%s\n", ui.Color.Green, c.New.Command, ui.Color.Reset)
+			} else {
+				fmt.Printf("    %s%s%s\n", ui.Color.White, ui.Msg.DiffMetadataChanged, ui.Color.Reset)
+			}
+			if c.Old.Disabled != c.New.Disabled {
+				state := ui.Msg.StateEnabled
+				if c.New.Disabled {
+					state = ui.Msg.StateDisabled
+				}
+				fmt.Printf("    %s→ %s%s\n", ui.Color.White, state, ui.Color.Reset)
+			}
+		case ChangeRemove:
+			fmt.Printf("%s- %s → %s%s\n", ui.Color.Red, c.Name, c.Old.Command, ui.Color.Reset)
+		}
+	}
+}
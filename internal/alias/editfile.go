@@ -0,0 +1,156 @@
+package alias
+
+import (
+	"bufio"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"quickalias/internal/ui"
+)
+
+// Keys of the indented metadata lines in an edit file.
+const (
+	editKeyDesc        = "desc"
+	editKeyTags        = "tags"
+	editKeyCategory    = "category"
+	editKeyAllowShadow = "allow-shadow"
+	editKeyDisabled    = "disabled"
+)
+
+// RenderEditFile renders aliases in the format opened by `qq edit`:
+//
+//	name = command
+//	    desc: what it does
+//	    tags: git, vcs
+//	    category: git
+//
+// Lines starting with # are comments. A command that spans several lines, starts or ends
+// with whitespace or starts with a double quote is written as a double-quoted string with
+// Go escapes (\n, \", \\), so the line keeps it exactly.
+func RenderEditFile(aliases []Alias) string {
+	var sb strings.Builder
+	for _, line := range strings.Split(ui.Msg.EditFileHeader, "\n") {
+		sb.WriteString("# " + line + "\n")
+	}
+	for _, a := range aliases {
+		sb.WriteString("\n" + a.Name + " = " + editCommand(a.Command) + "\n")
+		if a.Description != "" {
+			sb.WriteString("    " + editKeyDesc + ": " + a.Description + "\n")
+		}
+		if len(a.Tags) > 0 {
+			sb.WriteString("    " + editKeyTags + ": " + strings.Join(a.Tags, ", ") + "\n")
+		}
+		if a.Category != "" {
+			sb.WriteString("    " + editKeyCategory + ": " + a.Category + "\n")
+		}
+		if a.AllowShadow {
+			sb.WriteString("    " + editKeyAllowShadow + ": yes\n")
+		}
+		if a.Disabled {
+			sb.WriteString("    " + editKeyDisabled + ": yes\n")
+		}
+		if !a.Conditions.IsZero() {
+			for _, f := range a.Conditions.fields() {
+				if len(*f.values) > 0 {
+					sb.WriteString("    " + f.key + ": " + strings.Join(*f.values, ", ") + "\n")
+				}
+			}
+		}
+	}
+	return sb.String()
+}
+
+// ParseEditFile parses the format written by RenderEditFile. The aliases are returned
+// without level or provenance; errors name the offending line.
+func ParseEditFile(text string) ([]Alias, error) {
+	var aliases []Alias
+	seen := make(map[string]int)
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		// Indented lines are metadata of the alias above them.
+		if line[0] == ' ' || line[0] == '\t' {
+			if len(aliases) == 0 {
+				return nil, fmt.Errorf(ui.Msg.EditMetadataWithoutAlias, lineNo)
+			}
+			key, value, ok := strings.Cut(trimmed, ":")
+			if !ok {
+				return nil, fmt.Errorf(ui.Msg.EditInvalidLine, lineNo, trimmed)
+			}
+			a := &aliases[len(aliases)-1]
+			value = strings.TrimSpace(value)
+			key = strings.TrimSpace(key)
+			if a.Conditions == nil {
+				a.Conditions = &Conditions{}
+			}
+			if field := conditionField(a.Conditions, key); field != nil {
+				*field = SplitList(value)
+				continue
+			}
+			switch key {
+			case editKeyDesc:
+				a.Description = value
+			case editKeyTags:
+				a.Tags = NormalizeTags([]string{value})
+			case editKeyCategory:
+				a.Category = value
+			case editKeyAllowShadow:
+				a.AllowShadow = ui.IsYes(value)
+			case editKeyDisabled:
+				a.Disabled = ui.IsYes(value)
+			default:
+				return nil, fmt.Errorf(ui.Msg.EditUnknownKey, lineNo, key)
+			}
+			continue
+		}
+
+		name, command, ok := strings.Cut(trimmed, "=")
+		name, command = strings.TrimSpace(name), strings.TrimSpace(command)
+		if !ok || name == "" {
+			return nil, fmt.Errorf(ui.Msg.EditInvalidLine, lineNo, trimmed)
+		}
+		if previous, dup := seen[name]; dup {
+			return nil, fmt.Errorf(ui.Msg.EditDuplicateAlias, lineNo, name, previous)
+		}
+		if strings.HasPrefix(command, `"`) {
+			if unquoted, err := strconv.Unquote(command); err == nil {
+				command = unquoted
+			}
+		}
+		seen[name] = lineNo
+		aliases = append(aliases, Alias{Name: name, Command: command})
+	}
+	for i := range aliases {
+		if aliases[i].Conditions.IsZero() {
+			aliases[i].Conditions = nil
+		} else if err := aliases[i].Conditions.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	return aliases, scanner.Err()
+}
+
+// editCommand returns command as it is written after "name = ", quoted when the line
+// would not keep it as it is.
+func editCommand(command string) string {
+	if strings.ContainsAny(command, "\n\r") || strings.TrimSpace(command) != command || strings.HasPrefix(command, `"`) {
+		return strconv.Quote(command)
+	}
+	return command
+}
+
+// conditionField returns the condition list stored under key, or nil if key is not a condition.
+func conditionField(c *Conditions, key string) *[]string {
+	for _, f := range c.fields() {
+		if f.key == key {
+			return f.values
+		}
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package alias
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestEditFileRoundTrip(t *testing.T) {
+	aliases := []Alias{
+		{Name: "ll", Command: "ls -la", Description: "long list", Tags: []string{"fs", "ls"}, Category: "files"},
+		{Name: "multi", Command: "echo a\n  echo b\n"},
+		{Name: "pad", Command: "  ls\t"},
+		{Name: "quoted", Command: `"$EDITOR" file`},
+		{Name: "esc", Command: `printf '%s\n' "a\"b" \`, AllowShadow: true, Disabled: true},
+		{Name: "eq", Command: "FOO=1 env"},
+		{Name: "k", Command: "kubectl", Conditions: &Conditions{Hosts: []string{"web-*"}, Shells: []string{"zsh"}}},
+	}
+	text := RenderEditFile(aliases)
+	got, err := ParseEditFile(text)
+	if err != nil {
+		t.Fatalf("ParseEditFile: %v\n%s", err, text)
+	}
+	if !reflect.DeepEqual(got, aliases) {
+		t.Errorf("got  %+v\nwant %+v\nfrom\n%s", got, aliases, text)
+	}
+	if !strings.Contains(text, "\nmulti = \"echo a\\n  echo b\\n\"\n") {
+		t.Errorf("multi-line command not quoted:\n%s", text)
+	}
+}
+
+func TestParseEditFile(t *testing.T) {
+	tests := []struct {
+		name, text string
+		want       []Alias
+		err        string
+	}{
+		{name: "plain", text: "# comment\nll = ls -la  \n\ngs=git status\n",
+			want: []Alias{{Name: "ll", Command: "ls -la"}, {Name: "gs", Command: "git status"}}},
+		{name: "quoted", text: `x = "echo a\necho b"` + "\n",
+			want: []Alias{{Name: "x", Command: "echo a\necho b"}}},
+		{name: "not a quoted string", text: `x = "$A" "$B"` + "\n",
+			want: []Alias{{Name: "x", Command: `"$A" "$B"`}}},
+		{name: "metadata", text: "x = ls\n    desc: list\n    disabled: yes\n    os: linux\n",
+			want: []Alias{{Name: "x", Command: "ls", Description: "list", Disabled: true, Conditions: &Conditions{OS: []string{"linux"}}}}},
+		{name: "metadata without alias", text: "    desc: list\n", err: "1"},
+		{name: "unknown key", text: "x = ls\n    dsec: list\n", err: "2"},
+		{name: "missing command", text: "x ls\n", err: "1"},
+		{name: "duplicate", text: "x = ls\ny = ls\nx = ls -l\n", err: "3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseEditFile(tt.text)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("err = %v, want line %s", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseEditFile: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got  %+v\nwant %+v", got, tt.want)
+			}
+		})
+	}
+}
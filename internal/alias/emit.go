@@ -0,0 +1,38 @@
+package alias
+
+import (
+	"fmt"
+	"strings"
+
+	"quickalias/internal/shell"
+	"quickalias/internal/ui"
+)
+
+// InitScript returns the alias definitions that `qq init` emits for the shell of m.
+// Global aliases come first, then pack aliases, so that user aliases with the same name
+// override both. Disabled aliases and aliases whose conditions do not hold on m are
+// skipped, so an inactive user alias lets the pack or global one through. Aliases whose
+// name the shell cannot define are left out and returned as errors: the script is
+// evaluated, and a name such as "x;cmd" would run as a command.
+func InitScript(userAliases, packAliases, globalAliases []Alias, m Machine) (string, []error) {
+	var sb strings.Builder
+	var errs []error
+	for _, layer := range [][]Alias{globalAliases, packAliases, userAliases} {
+		for _, a := range layer {
+			if !a.Active(m) {
+				continue
+			}
+			if err := ValidateName(a.Name, m.Shell); err != nil {
+				errs = append(errs, fmt.Errorf(ui.Msg.InitAliasSkipped, a.Name, err))
+				continue
+			}
+			sb.WriteString(InitLine(a, m.Shell) + "\n")
+		}
+	}
+	return sb.String(), errs
+}
+
+// InitLine returns the exact line `qq init` emits for a single alias.
+func InitLine(a Alias, shellType string) string {
+	return shell.AliasLine(shellType, a.Name, a.Command)
+}
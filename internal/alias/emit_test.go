@@ -0,0 +1,42 @@
+package alias
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestInitScript(t *testing.T) {
+	global := []Alias{{Name: "ll", Command: "ls -l", Level: "global"}, {Name: "x;id", Command: "ls", Level: "global"}}
+	pack := []Alias{{Name: "gs", Command: "git status", Level: PackLevel("git")}}
+	user := []Alias{
+		{Name: "ll", Command: "ls -la", Level: "user"},
+		{Name: "off", Command: "ls", Level: "user", Disabled: true},
+		{Name: "g*", Command: "git", Level: "user"},
+		{Name: "`id`", Command: "ls", Level: "user"},
+	}
+	tests := []struct {
+		shell   string
+		lines   []string
+		skipped []string
+	}{
+		{"bash", []string{"alias ll='ls -l'", "alias gs='git status'", "alias ll='ls -la'", "alias g*='git'"}, []string{"x;id", "`id`"}},
+		{"fish", []string{"alias ll 'ls -l'", "alias gs 'git status'", "alias ll 'ls -la'"}, []string{"x;id", "g*", "`id`"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.shell, func(t *testing.T) {
+			script, errs := InitScript(user, pack, global, Machine{Shell: tt.shell})
+			if lines := strings.Split(strings.TrimSuffix(script, "\n"), "\n"); !reflect.DeepEqual(lines, tt.lines) {
+				t.Errorf("script lines = %q, want %q", lines, tt.lines)
+			}
+			if len(errs) != len(tt.skipped) {
+				t.Fatalf("errors = %v, want one for each of %q", errs, tt.skipped)
+			}
+			for i, name := range tt.skipped {
+				if !strings.Contains(errs[i].Error(), "'"+name+"'") {
+					t.Errorf("error %d = %v, want it to name %q", i, errs[i], name)
+				}
+			}
+		})
+	}
+}
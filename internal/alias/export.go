@@ -0,0 +1,127 @@
+package alias
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"quickalias/internal/ui"
+)
+
+// Formats accepted by `qq config export --format`.
+const (
+	ExportJSON  = "json"
+	ExportBash  = "bash"
+	ExportZsh   = "zsh"
+	ExportFish  = "fish"
+	ExportPOSIX = "posix"
+	ExportYAML  = "yaml"
+	ExportTOML  = "toml"
+)
+
+// ExportFormats lists the export formats, the default first.
+var ExportFormats = []string{ExportJSON, ExportBash, ExportZsh, ExportFish, ExportPOSIX, ExportYAML, ExportTOML}
+
+// exportExtensions maps file extensions to the export format they imply.
+var exportExtensions = map[string]string{
+	".json": ExportJSON,
+	".bash": ExportBash,
+	".zsh":  ExportZsh,
+	".fish": ExportFish,
+	".sh":   ExportPOSIX,
+	".yaml": ExportYAML,
+	".yml":  ExportYAML,
+	".toml": ExportTOML,
+}
+
+// ExportFormatFor returns the format implied by the extension of path, or JSON.
+func ExportFormatFor(path string) string {
+	if format, ok := exportExtensions[strings.ToLower(filepath.Ext(path))]; ok {
+		return format
+	}
+	return ExportJSON
+}
+
+// ExportExtension returns the file extension used for format by default.
+func ExportExtension(format string) string {
+	if format == ExportPOSIX {
+		return ".sh"
+	}
+	return "." + format
+}
+
+// RenderExport returns the aliases in the given export format. Shell formats use the
+// same quoting as `qq init`, so the file can be sourced where qq is not installed;
+// levels are kept as comments, and YAML and TOML carry every field like JSON does.
+func RenderExport(userAliases, globalAliases []Alias, format string) ([]byte, error) {
+	switch format {
+	case ExportJSON:
+		// Combine user and global aliases into one slice for export.
+		allAliases := append(append([]Alias{}, globalAliases...), userAliases...)
+		return json.MarshalIndent(allAliases, "", "  ") // Use 2 spaces for indentation.
+	case ExportBash, ExportZsh, ExportFish, ExportPOSIX, ExportYAML, ExportTOML:
+	default:
+		return nil, fmt.Errorf(ui.Msg.UnknownExportFormat, format, strings.Join(ExportFormats, ", "))
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# QuickAlias (qq) aliases, exported %s\n", time.Now().Format(time.RFC3339))
+	for _, layer := range []struct {
+		level   string
+		aliases []Alias
+	}{{"global", globalAliases}, {"user", userAliases}} {
+		if len(layer.aliases) == 0 {
+			continue
+		}
+		fmt.Fprintf(&sb, "\n# level: %s\n", layer.level)
+		for _, a := range layer.aliases {
+			if err := writeExported(&sb, a, format); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return []byte(sb.String()), nil
+}
+
+// writeExported writes one alias in a shell, YAML or TOML export.
+func writeExported(sb *strings.Builder, a Alias, format string) error {
+	switch format {
+	case ExportYAML, ExportTOML:
+		v, err := orderedJSON(a)
+		if err != nil {
+			return err
+		}
+		if format == ExportYAML {
+			yamlListItem(sb, v.([]field))
+		} else {
+			sb.WriteString("\n")
+			writeTOMLTable(sb, "aliases", v.([]field))
+		}
+		return nil
+	}
+
+	shellType := format
+	if format == ExportPOSIX {
+		shellType = "sh"
+	}
+	if a.Description != "" {
+		sb.WriteString("# " + strings.ReplaceAll(a.Description, "\n", " ") + "\n")
+	}
+	if !a.Conditions.IsZero() {
+		sb.WriteString("# if: " + a.Conditions.String() + "\n")
+	}
+	// Aliases using secrets are commented out: the export keeps only the placeholders,
+	// which mean nothing to a shell without qq. So are names the shell cannot define.
+	line := InitLine(a, shellType)
+	if a.Disabled {
+		line = "# (disabled) " + strings.ReplaceAll(line, "\n", "\n# ")
+	} else if ValidateName(a.Name, shellType) != nil {
+		line = "# (invalid name) " + strings.ReplaceAll(line, "\n", "\n# ")
+	} else if names := SecretNames(a.Command); len(names) > 0 {
+		line = "# (secret: " + strings.Join(names, ", ") + ") " + strings.ReplaceAll(line, "\n", "\n# ")
+	}
+	sb.WriteString(line + "\n")
+	return nil
+}
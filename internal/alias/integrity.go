@@ -0,0 +1,88 @@
+package alias
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"quickalias/internal/ui"
+)
+
+// CHECKSUM_EXT is appended to the path of the global store to name its checksum file, which
+// qq writes whenever it saves the store, in the format of sha256sum.
+const CHECKSUM_EXT = ".sha256"
+
+// UnverifiedStoreError is returned by CheckGlobalStore for a global store that must not be loaded.
+type UnverifiedStoreError struct {
+	Reason string
+}
+
+func (e *UnverifiedStoreError) Error() string {
+	return e.Reason
+}
+
+// unverified returns an UnverifiedStoreError with a formatted reason.
+func unverified(format string, args ...interface{}) error {
+	return &UnverifiedStoreError{Reason: fmt.Sprintf(format, args...)}
+}
+
+// ChecksumPath returns the path of the checksum file of the store at path.
+func ChecksumPath(path string) string {
+	return path + CHECKSUM_EXT
+}
+
+// CheckGlobalStore verifies the global store at path, whose contents are data, before its
+// aliases are loaded into every user's shell: the store and its directory must be owned by
+// root and not writable by everyone, and when the store has a checksum file it must match.
+// It reports whether a checksum was verified.
+func CheckGlobalStore(path string, data []byte) (bool, error) {
+	for _, p := range []string{filepath.Dir(path), path} {
+		info, err := os.Stat(p)
+		if err != nil {
+			return false, err
+		}
+		if info.Mode().Perm()&0002 != 0 {
+			return false, unverified(ui.Msg.GlobalStoreWorldWritable, p)
+		}
+		if uid, ok := fileOwner(info); ok && uid != 0 {
+			return false, unverified(ui.Msg.GlobalStoreNotRootOwned, p, uid)
+		}
+	}
+
+	checksumPath := ChecksumPath(path)
+	content, err := os.ReadFile(checksumPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil // The checksum is optional: stores saved before it existed have none.
+	}
+	if err != nil {
+		return false, err
+	}
+	fields := strings.Fields(string(content))
+	if len(fields) == 0 || len(fields[0]) != sha256.Size*2 {
+		return false, unverified(ui.Msg.GlobalStoreChecksumInvalid, checksumPath)
+	}
+	if sum := sha256.Sum256(data); !strings.EqualFold(fields[0], hex.EncodeToString(sum[:])) {
+		return false, unverified(ui.Msg.GlobalStoreChecksumMismatch, path, checksumPath)
+	}
+	return true, nil
+}
+
+// writeChecksum writes the checksum file of the store at path, which holds data.
+func writeChecksum(path string, data []byte) error {
+	sum := sha256.Sum256(data)
+	line := hex.EncodeToString(sum[:]) + "  " + filepath.Base(path) + "\n"
+	return os.WriteFile(ChecksumPath(path), []byte(line), 0644)
+}
+
+// GlobalStoreError returns why the global store was not loaded, if it failed verification.
+func (pm *PersistManager) GlobalStoreError() error {
+	var target *UnverifiedStoreError
+	if err := pm.loadErrors["global"]; errors.As(err, &target) {
+		return err
+	}
+	return nil
+}
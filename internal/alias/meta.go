@@ -0,0 +1,97 @@
+package alias
+
+import (
+	"sort"
+	"strings"
+)
+
+// NormalizeTags splits comma-separated tags, trims them and drops empty and duplicate entries,
+// so `--tag git,vcs --tag git` becomes [git vcs].
+func NormalizeTags(tags []string) []string {
+	var normalized []string
+	seen := make(map[string]bool)
+	for _, value := range tags {
+		for _, tag := range strings.Split(value, ",") {
+			tag = strings.TrimSpace(tag)
+			if tag == "" || seen[strings.ToLower(tag)] {
+				continue
+			}
+			seen[strings.ToLower(tag)] = true
+			normalized = append(normalized, tag)
+		}
+	}
+	return normalized
+}
+
+// HasTag reports whether the alias carries the given tag, ignoring case.
+func (a Alias) HasTag(tag string) bool {
+	for _, t := range a.Tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// Filter selects aliases for `qq list` and `qq search`. The zero Filter matches every alias.
+type Filter struct {
+	Keyword  string   // Substring of the name, command or description.
+	Tags     []string // Every tag must be present on the alias.
+	Category string   // Exact category, ignoring case.
+}
+
+// Active reports whether the filter restricts the result at all.
+func (f Filter) Active() bool {
+	return f.Keyword != "" || len(f.Tags) > 0 || f.Category != ""
+}
+
+// Match reports whether the alias passes the filter.
+func (f Filter) Match(a Alias) bool {
+	if f.Keyword != "" && !strings.Contains(a.Name, f.Keyword) && !strings.Contains(a.Command, f.Keyword) &&
+		!strings.Contains(strings.ToLower(a.Description), strings.ToLower(f.Keyword)) {
+		return false
+	}
+	for _, tag := range f.Tags {
+		if !a.HasTag(tag) {
+			return false
+		}
+	}
+	return f.Category == "" || strings.EqualFold(a.Category, f.Category)
+}
+
+// GroupByCategory splits aliases by category, keeping their order within each group.
+// Uncategorized aliases come first, followed by the categories in alphabetical order.
+func GroupByCategory(aliases []Alias) (categories []string, groups map[string][]Alias) {
+	groups = make(map[string][]Alias)
+	for _, a := range aliases {
+		if _, ok := groups[a.Category]; !ok && a.Category != "" {
+			categories = append(categories, a.Category)
+		}
+		groups[a.Category] = append(groups[a.Category], a)
+	}
+	sort.Strings(categories)
+	if len(groups[""]) > 0 {
+		categories = append([]string{""}, categories...)
+	}
+	return categories, groups
+}
+
+// Tags returns every tag used by the given aliases, sorted.
+func Tags(aliases []Alias) []string {
+	var all []string
+	for _, a := range aliases {
+		all = append(all, a.Tags...)
+	}
+	tags := NormalizeTags(all)
+	sort.Strings(tags)
+	return tags
+}
+
+// Categories returns every category used by the given aliases, sorted.
+func Categories(aliases []Alias) []string {
+	categories, _ := GroupByCategory(aliases)
+	if len(categories) > 0 && categories[0] == "" {
+		categories = categories[1:]
+	}
+	return categories
+}
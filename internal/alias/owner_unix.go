@@ -0,0 +1,16 @@
+//go:build unix
+
+package alias
+
+import (
+	"io/fs"
+	"syscall"
+)
+
+// fileOwner returns the uid that owns the file described by info.
+func fileOwner(info fs.FileInfo) (int, bool) {
+	if st, ok := info.Sys().(*syscall.Stat_t); ok {
+		return int(st.Uid), true
+	}
+	return 0, false
+}
@@ -0,0 +1,263 @@
+package alias
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"quickalias/internal/ui"
+)
+
+const (
+	// PACK_DIR is the directory in the user config that holds installed packs, one file each.
+	PACK_DIR = "packs"
+	// PackLevelPrefix starts the level of aliases that come from a pack, e.g. "pack:k8s".
+	PackLevelPrefix = "pack:"
+	// maxPackManifestSize bounds how much of a manifest is read from an archive.
+	maxPackManifestSize = 4 << 20
+)
+
+// packManifests are the names a pack manifest may have in a directory or archive.
+var packManifests = []string{"pack.json", "pack.yaml", "pack.yml", "pack.toml"}
+
+// packNamePattern restricts pack names to what can safely be used as a file name.
+var packNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// Pack is a named, versioned set of aliases shared as a single manifest (`qq pack install`).
+// Installed packs are loaded as their own layer, between global and user aliases, so a
+// user alias with the same name overrides the pack's.
+type Pack struct {
+	Name        string  `json:"name"`
+	Version     string  `json:"version,omitempty"`
+	Description string  `json:"description,omitempty"`
+	Author      string  `json:"author,omitempty"`
+	Homepage    string  `json:"homepage,omitempty"`
+	Source      string  `json:"source,omitempty"` // Index the pack was downloaded from; `qq pack update` looks there.
+	Aliases     []Alias `json:"aliases"`
+}
+
+// IsPackName reports whether name can be the name of a pack.
+func IsPackName(name string) bool {
+	return packNamePattern.MatchString(name)
+}
+
+// PackLevel returns the level of the aliases of the pack called name.
+func PackLevel(name string) string {
+	return PackLevelPrefix + name
+}
+
+// PackName returns the pack an alias comes from, or "" for user and global aliases.
+func (a Alias) PackName() string {
+	if name, ok := strings.CutPrefix(a.Level, PackLevelPrefix); ok {
+		return name
+	}
+	return ""
+}
+
+// DecodePack parses a pack manifest in the format given by the extension of path. The
+// manifest has the pack's metadata and its aliases under "aliases", with the same fields
+// as an alias store; the level of every alias is set to the pack's.
+func DecodePack(path string, data []byte) (Pack, error) {
+	var p Pack
+	switch storeFormat(path) {
+	case ExportJSON:
+		if err := json.Unmarshal(data, &p); err != nil {
+			return Pack{}, err
+		}
+	default:
+		var tree interface{}
+		var err error
+		if storeFormat(path) == ExportYAML {
+			tree, err = parseYAML(data)
+		} else {
+			tree, err = parseTOML(data)
+		}
+		if err != nil {
+			return Pack{}, err
+		}
+		if _, ok := tree.([]field); !ok {
+			return Pack{}, errors.New(ui.Msg.PackManifestNotMapping)
+		}
+		data, err := json.Marshal(toJSONValue(tree))
+		if err != nil {
+			return Pack{}, err
+		}
+		if err := json.Unmarshal(data, &p); err != nil {
+			return Pack{}, err
+		}
+	}
+
+	if !IsPackName(p.Name) {
+		return Pack{}, fmt.Errorf(ui.Msg.PackInvalidName, p.Name)
+	}
+	for i := range p.Aliases {
+		p.Aliases[i].Level = PackLevel(p.Name)
+	}
+	return p, nil
+}
+
+// ReadPack reads a pack from source: a manifest file, a directory holding pack.json (or
+// pack.yaml, pack.yml, pack.toml), or a .tar.gz/.tgz archive with such a manifest. In an
+// archive the manifest closest to the top is used, so packs may be wrapped in a directory.
+func ReadPack(source string) (Pack, error) {
+	info, err := os.Stat(source)
+	if err != nil {
+		return Pack{}, err
+	}
+
+	if info.IsDir() {
+		for _, name := range packManifests {
+			manifest := filepath.Join(source, name)
+			if data, err := os.ReadFile(manifest); err == nil {
+				return DecodePack(manifest, data)
+			}
+		}
+		return Pack{}, fmt.Errorf(ui.Msg.PackManifestNotFound, source, strings.Join(packManifests, ", "))
+	}
+
+	lower := strings.ToLower(source)
+	if strings.HasSuffix(lower, ".tar.gz") || strings.HasSuffix(lower, ".tgz") {
+		return readPackArchive(source)
+	}
+	data, err := os.ReadFile(source)
+	if err != nil {
+		return Pack{}, err
+	}
+	return DecodePack(source, data)
+}
+
+// readPackArchive reads the pack manifest from a gzip-compressed tar archive.
+func readPackArchive(source string) (Pack, error) {
+	file, err := os.Open(source)
+	if err != nil {
+		return Pack{}, err
+	}
+	defer file.Close()
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return Pack{}, err
+	}
+	defer gz.Close()
+
+	var manifest string
+	var data []byte
+	depth := -1
+	archive := tar.NewReader(gz)
+	for {
+		header, err := archive.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return Pack{}, err
+		}
+		name := path.Clean(strings.TrimPrefix(header.Name, "./"))
+		if header.Typeflag != tar.TypeReg || !isPackManifest(path.Base(name)) {
+			continue
+		}
+		if d := strings.Count(name, "/"); depth < 0 || d < depth {
+			content, err := io.ReadAll(io.LimitReader(archive, maxPackManifestSize+1))
+			if err != nil {
+				return Pack{}, err
+			}
+			if len(content) > maxPackManifestSize {
+				return Pack{}, fmt.Errorf(ui.Msg.PackManifestTooLarge, name)
+			}
+			manifest, data, depth = name, content, d
+		}
+	}
+	if manifest == "" {
+		return Pack{}, fmt.Errorf(ui.Msg.PackManifestNotFound, source, strings.Join(packManifests, ", "))
+	}
+	return DecodePack(manifest, data)
+}
+
+// isPackManifest reports whether name is one of the file names of a pack manifest.
+func isPackManifest(name string) bool {
+	for _, m := range packManifests {
+		if name == m {
+			return true
+		}
+	}
+	return false
+}
+
+// LoadPacks reads the packs installed in dir, sorted by name. Packs that cannot be read
+// are skipped and returned as errors.
+func LoadPacks(dir string) ([]Pack, []error) {
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	sort.Strings(files)
+	var packs []Pack
+	var errs []error
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err == nil {
+			var p Pack
+			if p, err = DecodePack(file, data); err == nil {
+				packs = append(packs, p)
+				continue
+			}
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", file, err))
+	}
+	return packs, errs
+}
+
+// PackAliases returns the aliases of packs as one layer. When several packs define the
+// same name, the pack that sorts first wins, so the layer holds every name once.
+func PackAliases(packs []Pack) []Alias {
+	aliases := []Alias{}
+	seen := make(map[string]bool)
+	for _, p := range packs {
+		for _, a := range p.Aliases {
+			if !seen[a.Name] {
+				seen[a.Name] = true
+				aliases = append(aliases, a)
+			}
+		}
+	}
+	return aliases
+}
+
+// FindPack returns the pack called name.
+func FindPack(name string, packs []Pack) (Pack, bool) {
+	for _, p := range packs {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return Pack{}, false
+}
+
+// PackList is the list of installed packs in the shape used by the machine-readable output formats.
+type PackList []PackInfo
+
+// PackInfo describes an installed pack for `qq pack list`.
+type PackInfo struct {
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	Description string `json:"description"`
+	Author      string `json:"author"`
+	Source      string `json:"source"`
+	Aliases     int    `json:"aliases"`
+	Overridden  int    `json:"overridden"` // Aliases replaced by a user alias or an earlier pack.
+}
+
+// TSVRows implements ui.TSVRecord: one row per pack with name, version, alias count,
+// overridden alias count, author and description.
+func (l PackList) TSVRows() [][]string {
+	rows := make([][]string, 0, len(l))
+	for _, p := range l {
+		rows = append(rows, []string{p.Name, p.Version, fmt.Sprint(p.Aliases), fmt.Sprint(p.Overridden), p.Author, p.Description})
+	}
+	return rows
+}
@@ -0,0 +1,216 @@
+package alias
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"quickalias/internal/ui"
+)
+
+// Config.Settings keys used by pack sources. Both hold comma-separated lists.
+const (
+	SettingPackSources = "pack_sources"      // Index URLs added with `qq pack add-source`.
+	SettingTrustedKeys = "pack_trusted_keys" // Public keys that downloaded packs must be signed with.
+)
+
+const (
+	// PACK_CACHE_DIR is the directory in PACK_DIR that keeps downloaded pack versions.
+	PACK_CACHE_DIR = "cache"
+	// maxPackDownloadSize bounds indexes, archives and signatures fetched from a source.
+	maxPackDownloadSize = 16 << 20
+)
+
+// packHTTPClient fetches indexes and packs; a source that hangs must not hang the shell.
+var packHTTPClient = &http.Client{Timeout: 30 * time.Second}
+
+// PackIndex is the static index served by a pack source: the releases it offers.
+type PackIndex struct {
+	Packs []PackRelease `json:"packs"`
+}
+
+// PackRelease is one version of a pack in an index. URL and Signature may be relative
+// to the index; the signature defaults to the URL with ".minisig" appended.
+type PackRelease struct {
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+	URL         string `json:"url"`
+	Signature   string `json:"signature,omitempty"`
+	Source      string `json:"-"` // The index the release was found in.
+}
+
+// SplitSetting returns the items of a comma- or whitespace-separated setting.
+func SplitSetting(value string) []string {
+	return strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ' ' || r == '\n' || r == '\t' })
+}
+
+// FetchIndex downloads the pack index at source and resolves the URLs of its releases.
+func FetchIndex(source string) (PackIndex, error) {
+	data, err := download(source)
+	if err != nil {
+		return PackIndex{}, err
+	}
+	var index PackIndex
+	if err := json.Unmarshal(data, &index); err != nil {
+		return PackIndex{}, fmt.Errorf(ui.Msg.PackIndexParseError, source, err)
+	}
+	base, err := url.Parse(source)
+	if err != nil {
+		return PackIndex{}, err
+	}
+	for i := range index.Packs {
+		r := &index.Packs[i]
+		if r.Signature == "" {
+			r.Signature = r.URL + ".minisig"
+		}
+		for _, ref := range []*string{&r.URL, &r.Signature} {
+			u, err := url.Parse(*ref)
+			if err != nil {
+				return PackIndex{}, fmt.Errorf(ui.Msg.PackIndexParseError, source, err)
+			}
+			*ref = base.ResolveReference(u).String()
+		}
+		r.Source = source
+	}
+	return index, nil
+}
+
+// Release returns the release of the pack called name with the given version, or its
+// newest release when version is empty.
+func (index PackIndex) Release(name, version string) (PackRelease, bool) {
+	var best PackRelease
+	found := false
+	for _, r := range index.Packs {
+		switch {
+		case r.Name != name:
+		case version != "":
+			if r.Version == version {
+				return r, true
+			}
+		case !found || CompareVersions(r.Version, best.Version) > 0:
+			best, found = r, true
+		}
+	}
+	return best, found
+}
+
+// CompareVersions compares two dotted versions such as 1.10.0 and v1.9, numerically where
+// both parts are numbers. It returns -1, 0 or 1.
+func CompareVersions(a, b string) int {
+	split := func(v string) []string {
+		return strings.FieldsFunc(strings.TrimPrefix(v, "v"), func(r rune) bool { return r == '.' || r == '-' || r == '+' })
+	}
+	pa, pb := split(a), split(b)
+	for i := 0; i < len(pa) || i < len(pb); i++ {
+		if i >= len(pa) {
+			return -1
+		}
+		if i >= len(pb) {
+			return 1
+		}
+		na, errA := strconv.Atoi(pa[i])
+		nb, errB := strconv.Atoi(pb[i])
+		switch {
+		case errA == nil && errB == nil && na != nb:
+			if na < nb {
+				return -1
+			}
+			return 1
+		case (errA != nil || errB != nil) && pa[i] != pb[i]:
+			if pa[i] < pb[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// FetchRelease returns the path of the verified file of a release, downloading it and its
+// signature into the pack cache unless that version is cached already. The signature is
+// checked against keys every time, so a cached pack whose key is no longer trusted is refused.
+func (pm *PersistManager) FetchRelease(r PackRelease, keys []PublicKey) (string, PublicKey, error) {
+	if !IsPackName(r.Name) || !IsPackName(r.Version) {
+		return "", PublicKey{}, fmt.Errorf(ui.Msg.PackInvalidRelease, r.Name, r.Version)
+	}
+	u, err := url.Parse(r.URL)
+	if err != nil {
+		return "", PublicKey{}, err
+	}
+	file := path.Base(u.Path)
+	if file == "." || file == "/" {
+		file = "pack.json"
+	}
+	dir := filepath.Join(pm.PacksPath(), PACK_CACHE_DIR, r.Name, r.Version)
+	target := filepath.Join(dir, file)
+	signaturePath := target + ".sig"
+
+	data, errData := os.ReadFile(target)
+	signature, errSig := os.ReadFile(signaturePath)
+	cached := errData == nil && errSig == nil
+	if !cached {
+		if data, err = download(r.URL); err != nil {
+			return "", PublicKey{}, err
+		}
+		if signature, err = download(r.Signature); err != nil {
+			return "", PublicKey{}, err
+		}
+	}
+
+	key, err := VerifySignature(data, signature, keys)
+	if err != nil {
+		return "", PublicKey{}, fmt.Errorf(ui.Msg.PackVerificationFailed, r.Name, r.Version, err)
+	}
+	if cached {
+		return target, key, nil
+	}
+
+	for _, d := range []string{pm.PacksPath(), filepath.Dir(filepath.Dir(dir)), filepath.Dir(dir), dir} {
+		if err := pm.MkdirUser(d); err != nil {
+			return "", PublicKey{}, fmt.Errorf(ui.Msg.PackWriteError, err)
+		}
+	}
+	if err := pm.writeUserFile(target, data); err != nil {
+		return "", PublicKey{}, fmt.Errorf(ui.Msg.PackWriteError, err)
+	}
+	if err := pm.writeUserFile(signaturePath, signature); err != nil {
+		return "", PublicKey{}, fmt.Errorf(ui.Msg.PackWriteError, err)
+	}
+	return target, key, nil
+}
+
+// download fetches an http(s) URL, refusing error statuses and oversized responses.
+func download(rawURL string) ([]byte, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf(ui.Msg.PackUnsupportedURL, rawURL)
+	}
+	resp, err := packHTTPClient.Get(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf(ui.Msg.PackDownloadFailed, rawURL, resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxPackDownloadSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxPackDownloadSize {
+		return nil, fmt.Errorf(ui.Msg.PackDownloadTooLarge, rawURL)
+	}
+	return data, nil
+}
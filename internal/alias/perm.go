@@ -0,0 +1,92 @@
+package alias
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"quickalias/internal/ui"
+)
+
+// SettingUmask is the Config.Settings key with the umask for files qq writes in the user
+// layer, in octal (e.g. "022" to make them readable by everyone).
+const SettingUmask = "umask"
+
+// DefaultUserUmask keeps the user layer private: directories 0700 and files 0600.
+// Aliases, backups and exports often hold hostnames, paths and worse.
+const DefaultUserUmask fs.FileMode = 0077
+
+// ParseUmask parses an octal umask such as "022" or "0077".
+func ParseUmask(value string) (fs.FileMode, error) {
+	mask, err := strconv.ParseUint(value, 8, 32)
+	if err != nil || mask > 0777 {
+		return 0, fmt.Errorf(ui.Msg.InvalidUmask, value)
+	}
+	return fs.FileMode(mask), nil
+}
+
+// UserUmask returns the umask set in the Config.Settings value setting, or DefaultUserUmask
+// when it is empty or invalid.
+func UserUmask(setting string) fs.FileMode {
+	if setting == "" {
+		return DefaultUserUmask
+	}
+	mask, err := ParseUmask(setting)
+	if err != nil {
+		return DefaultUserUmask
+	}
+	return mask
+}
+
+// SetUmask sets the umask applied to directories and files written to the user layer from
+// the Config.Settings value setting. Global stores are not affected: every user must be able
+// to read them.
+func (pm *PersistManager) SetUmask(setting string) {
+	pm.umask = UserUmask(setting)
+}
+
+// MkdirUser creates a directory of the user layer with the configured mode. Directories
+// that exist already keep theirs; qq doctor reports them when they are too open.
+func (pm *PersistManager) MkdirUser(dir string) error {
+	if _, err := os.Stat(dir); err == nil {
+		return nil
+	}
+	if err := os.MkdirAll(dir, 0777&^pm.umask); err != nil {
+		return err
+	}
+	os.Chmod(dir, 0777&^pm.umask) // MkdirAll is subject to the process umask as well.
+	pm.ChownUser(dir)
+	return nil
+}
+
+// writeUserFile writes a file of the user layer with the configured mode, also when it
+// replaces a file with a different mode, and gives it to the invoking user under sudo.
+func (pm *PersistManager) writeUserFile(path string, data []byte) error {
+	mode := 0666 &^ pm.umask
+	if err := os.WriteFile(path, data, mode); err != nil {
+		return err
+	}
+	if err := os.Chmod(path, mode); err != nil {
+		return err
+	}
+	pm.ChownUser(path)
+	return nil
+}
+
+// PermissiveFiles returns the files and directories under root, root included, that grant
+// permissions umask takes away. Symbolic links are not followed.
+func PermissiveFiles(root string, umask fs.FileMode) []string {
+	var found []string
+	filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.Type()&fs.ModeSymlink != 0 {
+			return nil
+		}
+		if info, err := d.Info(); err == nil && info.Mode().Perm()&umask != 0 {
+			found = append(found, path)
+		}
+		return nil
+	})
+	return found
+}
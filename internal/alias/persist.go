@@ -2,12 +2,14 @@ package alias
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"sort" // Dosyaları sıralamak için eklendi
-	"strings"
 	"time"
+
+	"quickalias/internal/ui"
 )
 
 const (
@@ -19,12 +21,21 @@ const (
 	MAX_BACKUPS = 5
 )
 
-// Alias represents a single alias entry with its name, command, creation date, and level.
+// Alias represents a single alias entry with its name, command, creation date, level and optional metadata.
 type Alias struct {
-	Name    string `json:"name"`
-	Command string `json:"command"`
-	Created string `json:"created"`
-	Level   string `json:"level"` // "user" or "global"
+	Name        string      `json:"name"`
+	Command     string      `json:"command"`
+	Created     string      `json:"created"`                // RFC 3339; older stores use "2006-01-02 15:04:05".
+	Updated     string      `json:"updated,omitempty"`      // RFC 3339 time of the last change.
+	CreatedBy   string      `json:"created_by,omitempty"`   // User who created the alias.
+	Host        string      `json:"host,omitempty"`         // Machine the alias was created on.
+	Level       string      `json:"level"`                  // "user" or "global"
+	AllowShadow bool        `json:"allow_shadow,omitempty"` // Saved with --allow-shadow: shadowing commands is intended.
+	Description string      `json:"description,omitempty"`  // What the alias is for, set with --desc.
+	Tags        []string    `json:"tags,omitempty"`         // Free-form labels, set with --tag.
+	Category    string      `json:"category,omitempty"`     // Heading under which `qq list` groups the alias.
+	Disabled    bool        `json:"disabled,omitempty"`     // Kept but not emitted by `qq init` (qq disable).
+	Conditions  *Conditions `json:"conditions,omitempty"`   // Where the alias is active; evaluated by `qq init`.
 }
 
 // GetGlobalConfigDir returns the path to the global configuration directory.
@@ -50,44 +61,165 @@ type PersistManager struct {
 	GlobalConfigPath string
 	UserAliases      *[]Alias // Pointer to QuickAlias's UserAliases
 	GlobalAliases    *[]Alias // Pointer to QuickAlias's GlobalAliases
+	PackAliases      *[]Alias // Pointer to QuickAlias's PackAliases: the aliases of every installed pack.
+	Packs            []Pack   // Installed packs, sorted by name.
+
+	loadErrors map[string]error // By level: stores that exist but could not be parsed.
+	umask      os.FileMode      // Permissions taken away from what is written to the user layer.
+	chownUser  bool             // Set under sudo: files in the user layer are handed back to the invoking user.
+	userOwner  int
+	groupOwner int
+}
+
+// SetUserOwner makes files written to the user layer owned by uid:gid.
+// It is used when qq runs as root through sudo on behalf of a user.
+func (pm *PersistManager) SetUserOwner(uid, gid int) {
+	pm.chownUser, pm.userOwner, pm.groupOwner = true, uid, gid
+}
+
+// ChownUser gives a file in the user layer to its owner set by SetUserOwner. Without an owner it does nothing.
+func (pm *PersistManager) ChownUser(path string) {
+	if pm.chownUser {
+		os.Chown(path, pm.userOwner, pm.groupOwner) // Best effort; the write itself already succeeded.
+	}
 }
 
 // NewPersistManager creates a new PersistManager instance.
-func NewPersistManager(userConfigPath, globalConfigPath string, userAliases, globalAliases *[]Alias) *PersistManager {
+func NewPersistManager(userConfigPath, globalConfigPath string, userAliases, globalAliases, packAliases *[]Alias) *PersistManager {
 	return &PersistManager{
 		UserConfigPath:   userConfigPath,
 		GlobalConfigPath: globalConfigPath,
 		UserAliases:      userAliases,
 		GlobalAliases:    globalAliases,
+		PackAliases:      packAliases,
+		umask:            DefaultUserUmask,
 	}
 }
 
-// LoadAliases reads user and global alias files into the provided alias slices.
+// LoadAliases reads user and global alias files and the installed packs into the provided
+// alias slices. A store that cannot be parsed is reported on stderr and protected from
+// being overwritten by SaveAliases until it is fixed; a broken pack is reported and skipped.
 func (pm *PersistManager) LoadAliases() {
-	// Load user aliases.
-	userAliasPath := filepath.Join(pm.UserConfigPath, ALIASES_FILE)
-	if data, err := os.ReadFile(userAliasPath); err == nil {
-		json.Unmarshal(data, pm.UserAliases)
+	pm.loadErrors = make(map[string]error)
+	for _, store := range []struct {
+		level, dir string
+		aliases    *[]Alias
+	}{{"user", pm.UserConfigPath, pm.UserAliases}, {"global", pm.GlobalConfigPath, pm.GlobalAliases}} {
+		path := StorePath(store.dir)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		if store.level == "global" {
+			if _, err := CheckGlobalStore(path, data); err != nil {
+				pm.loadErrors[store.level] = err // Reported by the caller; for qq init in the script itself.
+				continue
+			}
+		}
+		aliases, err := DecodeAliases(path, data)
+		if err != nil {
+			pm.loadErrors[store.level] = fmt.Errorf("%s: %w", path, err)
+			fmt.Fprintf(os.Stderr, "%s⚠️ %s%s\n", ui.Color.Yellow, fmt.Sprintf(ui.Msg.StoreLoadWarning, path, err), ui.Color.Reset)
+			continue
+		}
+		for i := range aliases {
+			if aliases[i].Level == "" { // Hand-written stores may leave it out.
+				aliases[i].Level = store.level
+			}
+			// A store with a bad name is not saved over; it has to be fixed by hand.
+			if err := ValidateStoredName(aliases[i].Name); err != nil && pm.loadErrors[store.level] == nil {
+				pm.loadErrors[store.level] = fmt.Errorf("%s: %w", path, err)
+				fmt.Fprintf(os.Stderr, "%s⚠️ %s%s\n", ui.Color.Yellow, fmt.Sprintf(ui.Msg.StoreInvalidName, path, err), ui.Color.Reset)
+			}
+		}
+		*store.aliases = aliases
+	}
+
+	packs, errs := LoadPacks(pm.PacksPath())
+	for _, err := range errs {
+		fmt.Fprintf(os.Stderr, "%s⚠️ %s%s\n", ui.Color.Yellow, fmt.Sprintf(ui.Msg.PackLoadWarning, err), ui.Color.Reset)
 	}
+	pm.setPacks(packs)
+}
+
+// PacksPath returns the directory that holds the installed packs.
+func (pm *PersistManager) PacksPath() string {
+	return filepath.Join(pm.UserConfigPath, PACK_DIR)
+}
+
+// setPacks replaces the installed packs and rebuilds the pack layer from them.
+func (pm *PersistManager) setPacks(packs []Pack) {
+	sort.Slice(packs, func(i, j int) bool { return packs[i].Name < packs[j].Name })
+	pm.Packs = packs
+	if pm.PackAliases != nil {
+		*pm.PackAliases = PackAliases(packs)
+	}
+}
+
+// InstallPack writes p to the pack directory as JSON, replacing an installed pack of the
+// same name, and adds it to the pack layer.
+func (pm *PersistManager) InstallPack(p Pack) error {
+	dir := pm.PacksPath()
+	if err := pm.MkdirUser(dir); err != nil {
+		return fmt.Errorf(ui.Msg.PackWriteError, err)
+	}
+
+	stored := p
+	stored.Aliases = make([]Alias, len(p.Aliases))
+	for i, a := range p.Aliases {
+		a.Level = "" // The level follows from the pack when it is loaded.
+		stored.Aliases[i] = a
+	}
+	data, err := json.MarshalIndent(stored, "", "  ") // Use 2 spaces for indentation
+	if err != nil {
+		return fmt.Errorf(ui.Msg.PackWriteError, err)
+	}
+	path := filepath.Join(dir, p.Name+".json")
+	if err := pm.writeUserFile(path, data); err != nil {
+		return fmt.Errorf(ui.Msg.PackWriteError, err)
+	}
+
+	packs := []Pack{p}
+	for _, installed := range pm.Packs {
+		if installed.Name != p.Name {
+			packs = append(packs, installed)
+		}
+	}
+	pm.setPacks(packs)
+	return nil
+}
 
-	// Load global aliases.
-	globalAliasPath := filepath.Join(pm.GlobalConfigPath, ALIASES_FILE)
-	if data, err := os.ReadFile(globalAliasPath); err == nil {
-		json.Unmarshal(data, pm.GlobalAliases)
+// RemovePack deletes the installed pack called name and drops its aliases from the pack layer.
+func (pm *PersistManager) RemovePack(name string) error {
+	if err := os.Remove(filepath.Join(pm.PacksPath(), name+".json")); err != nil {
+		return fmt.Errorf(ui.Msg.PackRemoveError, name, err)
+	}
+	var packs []Pack
+	for _, installed := range pm.Packs {
+		if installed.Name != name {
+			packs = append(packs, installed)
+		}
 	}
+	pm.setPacks(packs)
+	return nil
 }
 
-// SaveAliases writes the current aliases (user or global) to their respective JSON files.
+// SaveAliases writes the current aliases (user or global) to their store, in the format
+// of its file extension (see StorePath).
 func (pm *PersistManager) SaveAliases(level, errMsgProcess, errMsgWrite, errMsgCreateDir string) error {
+	if err := pm.loadErrors[level]; err != nil {
+		return fmt.Errorf(ui.Msg.StoreUnreadableRefuseSave, err)
+	}
+
 	var aliases []Alias
 	var configPath string
 
 	if level == "user" {
 		aliases = *pm.UserAliases
-		configPath = filepath.Join(pm.UserConfigPath, ALIASES_FILE)
+		configPath = StorePath(pm.UserConfigPath)
 	} else { // level == "global"
 		aliases = *pm.GlobalAliases
-		configPath = filepath.Join(pm.GlobalConfigPath, ALIASES_FILE)
+		configPath = StorePath(pm.GlobalConfigPath)
 
 		// Create global config directory if it doesn't exist (required for global aliases).
 		if err := os.MkdirAll(pm.GlobalConfigPath, 0755); err != nil {
@@ -95,22 +227,33 @@ func (pm *PersistManager) SaveAliases(level, errMsgProcess, errMsgWrite, errMsgC
 		}
 	}
 
-	data, err := json.MarshalIndent(aliases, "", "  ") // Use 2 spaces for indentation
+	previous, _ := os.ReadFile(configPath) // Comments of YAML and TOML stores are carried over.
+	data, err := EncodeAliases(configPath, aliases, previous)
 	if err != nil {
 		return fmt.Errorf(errMsgProcess, err)
 	}
 
-	if err := os.WriteFile(configPath, data, 0644); err != nil {
+	write := pm.writeUserFile
+	if level == "global" {
+		write = func(path string, data []byte) error {
+			if err := os.WriteFile(path, data, 0644); err != nil {
+				return err
+			}
+			return writeChecksum(path, data)
+		}
+	}
+	if err := write(configPath, data); err != nil {
 		return fmt.Errorf(errMsgWrite, err)
 	}
 
 	return nil
 }
 
-// CreateBackup creates a timestamped backup of the current aliases.
+// CreateBackup creates a timestamped backup of the current aliases of one level.
+// The level is part of the name, so both sides of a move can be backed up at once.
 func (pm *PersistManager) CreateBackup(level, errMsgProcess, errMsgWrite string) error {
-	timestamp := time.Now().Format("20060102_1504")
-	backupName := fmt.Sprintf("backup_%s.json", timestamp)
+	timestamp := time.Now().Format("20060102_150405")
+	backupName := fmt.Sprintf("backup_%s_%s.json", timestamp, level)
 	backupPath := filepath.Join(pm.UserConfigPath, BACKUP_DIR, backupName)
 
 	var aliases []Alias
@@ -125,7 +268,10 @@ func (pm *PersistManager) CreateBackup(level, errMsgProcess, errMsgWrite string)
 		return fmt.Errorf(errMsgProcess, err)
 	}
 
-	if err := os.WriteFile(backupPath, data, 0644); err != nil {
+	if err := pm.MkdirUser(filepath.Dir(backupPath)); err != nil {
+		return fmt.Errorf(errMsgWrite, err)
+	}
+	if err := pm.writeUserFile(backupPath, data); err != nil {
 		return fmt.Errorf(errMsgWrite, err)
 	}
 
@@ -156,65 +302,84 @@ func (pm *PersistManager) CleanOldBackups() {
 	}
 }
 
-// ShowBackups lists all available backup files.
-func (pm *PersistManager) ShowBackups(colorCyanBold, colorGreen, colorReset, colorYellow, msgAvailable, msgNotFound string) error {
+// ListBackups returns the paths of all backup files, oldest first.
+func (pm *PersistManager) ListBackups() ([]string, error) {
 	backupDir := filepath.Join(pm.UserConfigPath, BACKUP_DIR)
 	files, err := filepath.Glob(filepath.Join(backupDir, "backup_*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// ShowBackups lists all available backup files.
+func (pm *PersistManager) ShowBackups() error {
+	files, err := pm.ListBackups()
 	if err != nil {
 		// Use a generic error message, as the original msg.AvailableBackups was designed for success case.
 		return fmt.Errorf("Yedek dosyaları listelenirken hata oluştu: %w", err)
 	}
 
 	if len(files) == 0 {
-		fmt.Printf("%s%s%s\n", colorYellow, msgNotFound, colorReset)
+		fmt.Printf("%s%s%s\n", ui.Color.Yellow, ui.Msg.BackupsNotFound, ui.Color.Reset)
 		return nil
 	}
 
-	fmt.Printf("%s%s%s\n", colorCyanBold, msgAvailable, colorReset)
+	fmt.Printf("%s%s%s\n", ui.Color.Cyan+ui.Color.Bold, ui.Msg.AvailableBackups, ui.Color.Reset)
 	for i, file := range files {
-		fmt.Printf("%s%d.%s %s%s%s\n", colorGreen, i+1, colorReset, colorCyanBold, filepath.Base(file), colorReset)
+		fmt.Printf("%s%d.%s %s%s%s\n", ui.Color.Green, i+1, ui.Color.Reset, ui.Color.Cyan+ui.Color.Bold, filepath.Base(file), ui.Color.Reset)
 	}
 
 	return nil
 }
 
-// ExportConfig exports all aliases (user and global) to a single JSON file.
-func (pm *PersistManager) ExportConfig(path, errMsgProcess, errMsgWrite, successMsg, colorGreen, colorBold, colorReset string) error {
-	// Combine user and global aliases into one slice for export.
-	allAliases := append(*pm.GlobalAliases, *pm.UserAliases...)
-	data, err := json.MarshalIndent(allAliases, "", "  ") // Use 2 spaces for indentation.
+// ExportConfig exports all aliases (user and global) to a single file in the given format
+// (see ExportFormats).
+func (pm *PersistManager) ExportConfig(path, format string) error {
+	data, err := RenderExport(*pm.UserAliases, *pm.GlobalAliases, format)
 	if err != nil {
-		return fmt.Errorf(errMsgProcess, err)
+		return fmt.Errorf(ui.Msg.ExportDataProcessingError, err)
 	}
 
 	// Write the combined alias data to the specified file.
-	if err := os.WriteFile(path, data, 0644); err != nil {
-		return fmt.Errorf(errMsgWrite, err)
+	if err := pm.writeUserFile(path, data); err != nil {
+		return fmt.Errorf(ui.Msg.ExportFileWriteError, err)
 	}
 
-	fmt.Printf("%s✅ %s: %s%s%s\n", colorGreen, successMsg, colorBold, path, colorReset)
+	fmt.Printf("%s✅ %s: %s%s%s\n", ui.Color.Green, ui.Msg.ExportConfigSuccess, ui.Color.Bold, path, ui.Color.Reset)
 	return nil
 }
 
-// ImportConfig imports aliases from a JSON file, separating them into user and global levels.
-// It prompts for user confirmation and creates backups before importing.
-func (pm *PersistManager) ImportConfig(path string, colorYellow, colorRed, colorGreen, colorReset, confirmMsg, cancelMsg, readErr, parseErr, importSuccessMsg string) error {
+// ImportConfig imports aliases from a JSON, YAML or TOML file (by extension), separating them into user and global levels.
+// Alias names are validated for shellType first; it prompts for user confirmation and creates backups before importing.
+func (pm *PersistManager) ImportConfig(path, shellType string) error {
 	data, err := os.ReadFile(path)
 	if err != nil {
-		return fmt.Errorf(readErr, err)
+		return fmt.Errorf(ui.Msg.ImportFileReadError, err)
 	}
 
-	var aliases []Alias
-	if err := json.Unmarshal(data, &aliases); err != nil {
-		return fmt.Errorf(parseErr, err)
+	aliases, err := DecodeAliases(path, data)
+	if err != nil {
+		return fmt.Errorf(ui.Msg.ImportFileParseError, err)
 	}
 
-	fmt.Printf("%s"+confirmMsg+"%s", colorYellow, len(aliases), colorReset)
-	var response string
-	fmt.Scanln(&response) // Read user input for confirmation.
-	if strings.ToLower(response) != "e" && strings.ToLower(response) != "evet" && strings.ToLower(response) != "y" {
-		fmt.Printf("%s❌ %s%s\n", colorRed, cancelMsg, colorReset)
-		return nil
+	// Refuse the whole import if any name is invalid, so the stores never contain broken aliases.
+	if errs := ValidateAliases(aliases, shellType); len(errs) > 0 {
+		for _, err := range errs {
+			fmt.Printf("%s❌ %v%s\n", ui.Color.Red, err, ui.Color.Reset)
+		}
+		return fmt.Errorf(ui.Msg.ImportValidationFailed, len(errs))
+	}
+	for _, a := range aliases {
+		PrintCommandWarnings(a.Name, CheckCommand(a.Command))
+	}
+
+	if err := ui.Confirm(fmt.Sprintf("%s"+ui.Msg.ImportConfirmation+"%s", ui.Color.Yellow, len(aliases), ui.Color.Reset)); err != nil {
+		if errors.Is(err, ui.ErrCancelled) {
+			fmt.Printf("%s❌ %s%s\n", ui.Color.Red, ui.Msg.OperationCancelled, ui.Color.Reset)
+		}
+		return err
 	}
 
 	// Create backups of current aliases before overwriting.
@@ -247,8 +412,8 @@ func (pm *PersistManager) ImportConfig(path string, colorYellow, colorRed, color
 		return err
 	}
 
-	fmt.Printf("%s✅ %s%s\n", colorGreen,
-		fmt.Sprintf(importSuccessMsg, len(aliases), userCount, globalCount, colorReset),
-		colorReset)
+	fmt.Printf("%s✅ %s%s\n", ui.Color.Green,
+		fmt.Sprintf(ui.Msg.ImportSuccess, len(aliases), userCount, globalCount),
+		ui.Color.Reset)
 	return nil
 }
@@ -0,0 +1,114 @@
+package alias
+
+import (
+	"fmt"
+	"os"
+	"os/user"
+	"sort"
+	"time"
+
+	"quickalias/internal/ui"
+)
+
+// legacyTimeFormat is the format Created was stored in before timestamps became RFC 3339.
+const legacyTimeFormat = "2006-01-02 15:04:05"
+
+// Sort keys accepted by `qq list --sort`.
+const (
+	SortName    = "name"
+	SortCreated = "created"
+	SortUpdated = "updated"
+)
+
+// SortKeys lists the accepted sort keys, offered by shell completion.
+var SortKeys = []string{SortName, SortCreated, SortUpdated}
+
+// ParseTime parses a stored timestamp, accepting RFC 3339 and the legacy format.
+func ParseTime(value string) (time.Time, bool) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, true
+	}
+	if t, err := time.ParseInLocation(legacyTimeFormat, value, time.Local); err == nil {
+		return t, true
+	}
+	return time.Time{}, false
+}
+
+// Stamp records provenance on a new or changed alias. When previous is the definition being
+// replaced at the same level, its creation time, author and host are kept and only Updated moves.
+func Stamp(a *Alias, previous *Alias, now time.Time) {
+	timestamp := now.Format(time.RFC3339)
+	if previous != nil && previous.Created != "" {
+		a.Created, a.CreatedBy, a.Host = previous.Created, previous.CreatedBy, previous.Host
+	} else {
+		a.Created, a.CreatedBy, a.Host = timestamp, currentUsername(), currentHostname()
+	}
+	a.Updated = timestamp
+}
+
+// currentUsername returns the user running qq. Under sudo, the invoking user is the author.
+func currentUsername() string {
+	if sudoUser := os.Getenv("SUDO_USER"); sudoUser != "" {
+		return sudoUser
+	}
+	if u, err := user.Current(); err == nil {
+		return u.Username
+	}
+	return ""
+}
+
+// currentHostname returns the machine's hostname, or "" if it is unknown.
+func currentHostname() string {
+	host, _ := os.Hostname()
+	return host
+}
+
+// lastChange returns when the alias was last modified, falling back to its creation time.
+func (a Alias) lastChange() string {
+	if a.Updated != "" {
+		return a.Updated
+	}
+	return a.Created
+}
+
+// SortAliases returns a sorted copy of aliases. Names sort alphabetically;
+// created and updated put the most recent alias first.
+func SortAliases(aliases []Alias, key string) ([]Alias, error) {
+	sorted := append([]Alias{}, aliases...)
+	newestFirst := func(value func(Alias) string) func(i, j int) bool {
+		return func(i, j int) bool {
+			ti, _ := ParseTime(value(sorted[i]))
+			tj, _ := ParseTime(value(sorted[j]))
+			return ti.After(tj)
+		}
+	}
+
+	switch key {
+	case "", SortName:
+		sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+	case SortCreated:
+		sort.SliceStable(sorted, newestFirst(func(a Alias) string { return a.Created }))
+	case SortUpdated:
+		sort.SliceStable(sorted, newestFirst(Alias.lastChange))
+	default:
+		return nil, fmt.Errorf(ui.Msg.InvalidSortKey, key)
+	}
+	return sorted, nil
+}
+
+// SortPackAliases sorts the aliases of every pack like SortAliases. The packs keep their
+// order, so each pack's aliases stay together.
+func SortPackAliases(aliases []Alias, key string) ([]Alias, error) {
+	sorted, err := SortAliases(aliases, key)
+	if err != nil {
+		return nil, err
+	}
+	order := make(map[string]int)
+	for _, a := range aliases {
+		if _, ok := order[a.Level]; !ok {
+			order[a.Level] = len(order)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool { return order[sorted[i].Level] < order[sorted[j].Level] })
+	return sorted, nil
+}
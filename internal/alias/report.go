@@ -0,0 +1,71 @@
+package alias
+
+import (
+	"strconv"
+	"strings"
+)
+
+// AliasList is a list of aliases in the shape used by the machine-readable output formats.
+type AliasList []Alias
+
+// TSVRows implements ui.TSVRecord: one row per alias with name, command, level, creation date,
+// description, category, comma-separated tags, last update, author, host and disabled state.
+func (l AliasList) TSVRows() [][]string {
+	rows := make([][]string, 0, len(l))
+	for _, a := range l {
+		rows = append(rows, []string{a.Name, a.Command, a.Level, a.Created, a.Description, a.Category, strings.Join(a.Tags, ","),
+			a.Updated, a.CreatedBy, a.Host, strconv.FormatBool(a.Disabled)})
+	}
+	return rows
+}
+
+// FilterAliases returns the aliases that pass the filter.
+func FilterAliases(aliases []Alias, filter Filter) []Alias {
+	matches := []Alias{}
+	for _, a := range aliases {
+		if filter.Match(a) {
+			matches = append(matches, a)
+		}
+	}
+	return matches
+}
+
+// Status is the data shown by `qq status`, independent of how it is presented.
+type Status struct {
+	UserAliases      int       `json:"user_aliases"`
+	PackAliases      int       `json:"pack_aliases"`
+	Packs            int       `json:"packs"` // Installed packs.
+	GlobalAliases    int       `json:"global_aliases"`
+	Conflicts        []string  `json:"conflicts"`
+	ShellType        string    `json:"shell_type"`
+	ShellIntegration bool      `json:"shell_integration"`
+	Findings         []Finding `json:"findings"` // Shadowing detected by Lint.
+}
+
+// NewStatus collects the status information for the given alias sets and number of packs.
+func NewStatus(userAliases, packAliases, globalAliases []Alias, packs int, shellType string, initialized bool) Status {
+	return Status{
+		UserAliases:      len(userAliases),
+		PackAliases:      len(packAliases),
+		Packs:            packs,
+		GlobalAliases:    len(globalAliases),
+		Conflicts:        FindConflicts(userAliases, globalAliases),
+		ShellType:        shellType,
+		ShellIntegration: initialized,
+		Findings:         Lint(userAliases, globalAliases, shellType),
+	}
+}
+
+// TSVRows implements ui.TSVRecord as key/value pairs.
+func (s Status) TSVRows() [][]string {
+	return [][]string{
+		{"user_aliases", strconv.Itoa(s.UserAliases)},
+		{"pack_aliases", strconv.Itoa(s.PackAliases)},
+		{"packs", strconv.Itoa(s.Packs)},
+		{"global_aliases", strconv.Itoa(s.GlobalAliases)},
+		{"conflicts", strings.Join(s.Conflicts, ",")},
+		{"shell_type", s.ShellType},
+		{"shell_integration", strconv.FormatBool(s.ShellIntegration)},
+		{"shadowing", strconv.Itoa(len(s.Findings))},
+	}
+}
@@ -0,0 +1,106 @@
+package alias
+
+import "strings"
+
+// Resolution explains how the shell resolves an alias name: which definition wins,
+// what it overrides and what `qq init` hands to the shell.
+type Resolution struct {
+	Name      string          `json:"name"`
+	Effective Alias           `json:"effective"`
+	Shadowed  []Alias         `json:"shadowed"` // Definitions in lower layers, overridden by Effective.
+	Disabled  []Alias         `json:"disabled"` // Disabled definitions, skipped by `qq init`.
+	Inactive  []InactiveAlias `json:"inactive"` // Definitions whose conditions do not hold on this machine.
+	Shadows   []Finding       `json:"shadows"`  // Builtins and $PATH commands hidden by the alias.
+	ShellType string          `json:"shell_type"`
+	InitLine  string          `json:"init_line"` // Empty when every definition is disabled.
+}
+
+// InactiveAlias is a definition whose conditions do not hold, with the reasons why.
+type InactiveAlias struct {
+	Alias   Alias    `json:"alias"`
+	Reasons []string `json:"reasons"`
+}
+
+// Resolve looks name up in every layer, highest precedence first; the first enabled
+// definition whose conditions hold on m wins. It returns false if no layer defines the alias.
+func Resolve(name string, userAliases, packAliases, globalAliases []Alias, m Machine) (Resolution, bool) {
+	shellType := m.Shell
+	enabled, disabled, inactive := []Alias{}, []Alias{}, []InactiveAlias{}
+	for _, layer := range [][]Alias{userAliases, packAliases, globalAliases} {
+		a, ok := FindAlias(name, layer)
+		if !ok {
+			continue
+		}
+		if a.Disabled {
+			disabled = append(disabled, a)
+		} else if reasons := a.InactiveReasons(m); len(reasons) > 0 {
+			inactive = append(inactive, InactiveAlias{Alias: a, Reasons: reasons})
+		} else {
+			enabled = append(enabled, a)
+		}
+	}
+	switch {
+	case len(enabled) == 0 && len(disabled) == 0 && len(inactive) == 0:
+		return Resolution{}, false
+	case len(enabled) == 0:
+		var effective Alias // Nothing takes effect; report the highest definition.
+		if len(disabled) > 0 {
+			effective = disabled[0]
+		} else {
+			effective = inactive[0].Alias
+		}
+		return Resolution{Name: name, Effective: effective, Shadowed: enabled, Disabled: disabled, Inactive: inactive,
+			Shadows: []Finding{}, ShellType: shellType}, true
+	}
+
+	effective := enabled[0]
+	lintShell := shellType
+	if effective.Level == "global" {
+		lintShell = "" // Global aliases are loaded by users of every shell.
+	}
+	shadows := ShadowedBy(effective, lintShell)
+	if shadows == nil {
+		shadows = []Finding{}
+	}
+	return Resolution{
+		Name:      name,
+		Effective: effective,
+		Shadowed:  enabled[1:],
+		Disabled:  disabled,
+		Inactive:  inactive,
+		Shadows:   shadows,
+		ShellType: shellType,
+		InitLine:  InitLine(effective, shellType),
+	}, true
+}
+
+// TSVRows implements ui.TSVRecord as key/value pairs.
+func (r Resolution) TSVRows() [][]string {
+	var shadowed []string
+	for _, a := range r.Shadowed {
+		shadowed = append(shadowed, a.Level)
+	}
+	var disabled []string
+	for _, a := range r.Disabled {
+		disabled = append(disabled, a.Level)
+	}
+	var inactive []string
+	for _, i := range r.Inactive {
+		inactive = append(inactive, i.Alias.Level)
+	}
+	var shadows []string
+	for _, f := range r.Shadows {
+		shadows = append(shadows, f.Kind+":"+f.Target)
+	}
+	return [][]string{
+		{"name", r.Name},
+		{"level", r.Effective.Level},
+		{"command", r.Effective.Command},
+		{"shadowed", strings.Join(shadowed, ",")},
+		{"disabled", strings.Join(disabled, ",")},
+		{"inactive", strings.Join(inactive, ",")},
+		{"shadows", strings.Join(shadows, ",")},
+		{"shell_type", r.ShellType},
+		{"init_line", r.InitLine},
+	}
+}
@@ -0,0 +1,419 @@
+package alias
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"quickalias/internal/ui"
+)
+
+// SECRETS_FILE is the file in the user config that holds the values of secret placeholders,
+// one name=value per line. It is only read while no one but its owner can access it.
+const SECRETS_FILE = "secrets"
+
+// secretPlaceholder matches ${secret:name} in a command. qq init replaces it with the
+// secret's value, so the stores and exports only ever hold the name.
+var secretPlaceholder = regexp.MustCompile(`\$\{secret:([A-Za-z0-9_.-]+)\}`)
+
+// secretNamePattern restricts secret names to what a placeholder can refer to.
+var secretNamePattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// secretPatterns recognise credentials with a well-known shape. They are checked in order
+// and each finding is reported once by its label.
+var secretPatterns = []struct {
+	label   string
+	pattern *regexp.Regexp
+}{
+	{"GitHub token", regexp.MustCompile(`\b(gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{22,})`)},
+	{"GitLab token", regexp.MustCompile(`\bglpat-[A-Za-z0-9_-]{20,}`)},
+	{"AWS access key", regexp.MustCompile(`\b(AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{"Slack token", regexp.MustCompile(`\bxox[abprs]-[A-Za-z0-9-]{10,}`)},
+	{"Stripe key", regexp.MustCompile(`\b[rs]k_(live|test)_[A-Za-z0-9]{16,}`)},
+	{"Google API key", regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}`)},
+	{"API key (sk-…)", regexp.MustCompile(`\bsk-[A-Za-z0-9_-]{20,}`)},
+	{"JSON Web Token", regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{10,}\.eyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}`)},
+	{"private key", regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----`)},
+	{"Authorization header", regexp.MustCompile(`(?i)authorization:\s*(bearer|basic|token)\s+[^\s'"$][^\s'"]*`)},
+	{"password in URL", regexp.MustCompile(`\b[a-z][a-z0-9+.-]*://[^\s/:@$]+:[^\s/@$]+@`)},
+	{"password or token assignment", regexp.MustCompile(`(?i)\b(password|passwd|secret|token|api[_-]?key|access[_-]?key)["']?\s*[=:]\s*["']?[^\s'"$]{6,}`)},
+}
+
+// Random-looking words at least highEntropyMinLength long with at least highEntropyBits
+// of Shannon entropy per character are reported as possible secrets.
+const (
+	highEntropyMinLength = 20
+	highEntropyBits      = 4.0
+)
+
+// highEntropyWord matches the words that are candidates for the entropy check: base64 and
+// hex alphabets without path separators, so paths and URLs are not flagged.
+var highEntropyWord = regexp.MustCompile(`[A-Za-z0-9+_=-]{20,}`)
+
+// DetectSecrets returns what in command looks like a secret: tokens with a known shape
+// and long random-looking strings. Secret placeholders are ignored.
+func DetectSecrets(command string) []string {
+	command = secretPlaceholder.ReplaceAllString(command, "")
+	var found []string
+	for _, p := range secretPatterns {
+		if p.pattern.MatchString(command) {
+			found = append(found, p.label)
+		}
+	}
+	if len(found) > 0 {
+		return found
+	}
+	for _, word := range highEntropyWord.FindAllString(command, -1) {
+		if len(word) >= highEntropyMinLength && hasLettersAndDigits(word) && shannonEntropy(word) >= highEntropyBits {
+			return []string{"high-entropy string"}
+		}
+	}
+	return nil
+}
+
+// shannonEntropy returns the entropy of s in bits per character.
+func shannonEntropy(s string) float64 {
+	counts := make(map[rune]int)
+	for _, r := range s {
+		counts[r]++
+	}
+	n := float64(len(s))
+	var bits float64
+	for _, c := range counts {
+		p := float64(c) / n
+		bits -= p * math.Log2(p)
+	}
+	return bits
+}
+
+// hasLettersAndDigits reports whether s mixes letters and digits, which words and
+// identifiers rarely do at length but generated tokens almost always do.
+func hasLettersAndDigits(s string) bool {
+	return strings.ContainsAny(s, "0123456789") && strings.IndexFunc(s, func(r rune) bool {
+		return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+	}) >= 0
+}
+
+// IsSecretName reports whether name can be used in a ${secret:name} placeholder.
+func IsSecretName(name string) bool {
+	return secretNamePattern.MatchString(name)
+}
+
+// SecretNames returns the names of the secrets command refers to, in order of first use.
+func SecretNames(command string) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, m := range secretPlaceholder.FindAllStringSubmatch(command, -1) {
+		if !seen[m[1]] {
+			seen[m[1]] = true
+			names = append(names, m[1])
+		}
+	}
+	return names
+}
+
+// SecretResolver looks up the values of secret placeholders: first in the secret file,
+// then in pass (as quickalias/<name>) and finally with secret-tool (service quickalias,
+// name <name>). Values are cached, so every secret is looked up once per run.
+type SecretResolver struct {
+	File    string
+	FileErr error // Why the secret file was ignored, if it was; set by the first lookup.
+
+	loaded   bool
+	values   map[string]string // The secret file.
+	resolved map[string]string // Every secret found so far, wherever it came from.
+	missing  map[string]bool
+}
+
+// NewSecretResolver returns a resolver reading the secret file at path.
+func NewSecretResolver(path string) *SecretResolver {
+	return &SecretResolver{File: path, resolved: make(map[string]string), missing: make(map[string]bool)}
+}
+
+// Lookup returns the value of the secret called name.
+func (r *SecretResolver) Lookup(name string) (string, error) {
+	if v, ok := r.resolved[name]; ok {
+		return v, nil
+	}
+	if r.missing[name] {
+		return "", fmt.Errorf(ui.Msg.SecretNotFound, name)
+	}
+	r.load()
+	if v, ok := r.values[name]; ok {
+		r.resolved[name] = v
+		return v, nil
+	}
+	if out, err := runSecretHelper("pass", "show", "quickalias/"+name); err == nil {
+		v, _, _ := strings.Cut(out, "\n")
+		r.resolved[name] = v
+		return v, nil
+	}
+	if out, err := runSecretHelper("secret-tool", "lookup", "service", "quickalias", "name", name); err == nil {
+		v := strings.TrimRight(out, "\n")
+		r.resolved[name] = v
+		return v, nil
+	}
+	r.missing[name] = true
+	return "", fmt.Errorf(ui.Msg.SecretNotFound, name)
+}
+
+// load reads the secret file the first time it is needed.
+func (r *SecretResolver) load() {
+	if !r.loaded {
+		r.loaded = true
+		r.values, r.FileErr = ReadSecretFile(r.File)
+	}
+}
+
+// concealMinLength is the length below which Conceal leaves a secret's value alone, since
+// it would match ordinary words and numbers in commands.
+const concealMinLength = 6
+
+// Conceal replaces the values of the secrets in the secret file and of every secret looked
+// up so far with their placeholders, e.g. in commands read back from the running shell,
+// where qq init has filled them in. Longer values are replaced first.
+func (r *SecretResolver) Conceal(command string) string {
+	r.load()
+	known := make(map[string]string)
+	for _, values := range []map[string]string{r.values, r.resolved} {
+		for name, v := range values {
+			if len(v) >= concealMinLength {
+				known[name] = v
+			}
+		}
+	}
+	names := SortedSecretNames(known)
+	sort.SliceStable(names, func(i, j int) bool { return len(known[names[i]]) > len(known[names[j]]) })
+	for _, name := range names {
+		command = strings.ReplaceAll(command, known[name], "${secret:"+name+"}")
+	}
+	return command
+}
+
+// Learn records the secret values a command filled in from template, a command with
+// placeholders, when command is what qq init makes of it. It reports whether command
+// matches, which tells a managed alias from a different one without running a password
+// manager. The values are only kept for Conceal and ShortSecret.
+func (r *SecretResolver) Learn(template, command string) bool {
+	names := SecretNames(template)
+	if len(names) == 0 {
+		return template == command
+	}
+	var pattern strings.Builder
+	pattern.WriteString("^")
+	last := 0
+	for _, loc := range secretPlaceholder.FindAllStringIndex(template, -1) {
+		pattern.WriteString(regexp.QuoteMeta(template[last:loc[0]]) + "(.+)")
+		last = loc[1]
+	}
+	pattern.WriteString(regexp.QuoteMeta(template[last:]) + "$")
+	m := regexp.MustCompile(pattern.String()).FindStringSubmatch(command)
+	if m == nil {
+		return false
+	}
+	learnt := make(map[string]string)
+	for i, placeholder := range secretPlaceholder.FindAllStringSubmatch(template, -1) {
+		if v, ok := learnt[placeholder[1]]; ok && v != m[i+1] {
+			return false // A secret used twice must have the same value both times.
+		}
+		learnt[placeholder[1]] = m[i+1]
+	}
+	for name, v := range learnt {
+		r.resolved[name] = v
+	}
+	return true
+}
+
+// ShortSecret returns the name of a known secret, from the secret file or learnt so far,
+// whose value is too short for Conceal and appears in command outside its placeholders.
+// Such a command cannot be stored without the secret in plain text.
+func (r *SecretResolver) ShortSecret(command string) (string, bool) {
+	r.load()
+	command = secretPlaceholder.ReplaceAllString(command, "")
+	for _, values := range []map[string]string{r.values, r.resolved} {
+		for _, name := range SortedSecretNames(values) {
+			if v := values[name]; v != "" && len(v) < concealMinLength && strings.Contains(command, v) {
+				return name, true
+			}
+		}
+	}
+	return "", false
+}
+
+// runSecretHelper runs a password manager if it is installed and returns its output.
+// Its errors go to the terminal, since it may need to ask for a passphrase.
+func runSecretHelper(name string, args ...string) (string, error) {
+	if _, err := exec.LookPath(name); err != nil {
+		return "", err
+	}
+	cmd := exec.Command(name, args...)
+	cmd.Stderr = os.Stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	if len(out) == 0 {
+		return "", errors.New(name + ": empty output")
+	}
+	return string(out), nil
+}
+
+// ResolveSecrets returns aliases with their secret placeholders replaced by the secrets'
+// values. Aliases using a secret that cannot be found are left out and returned as errors,
+// so a missing secret never ends up in the shell as a literal placeholder.
+func ResolveSecrets(aliases []Alias, r *SecretResolver) ([]Alias, []error) {
+	resolved := make([]Alias, 0, len(aliases))
+	var errs []error
+	for _, a := range aliases {
+		if a.Disabled || !secretUser(a) {
+			resolved = append(resolved, a)
+			continue
+		}
+		var missing error
+		a.Command = secretPlaceholder.ReplaceAllStringFunc(a.Command, func(m string) string {
+			name := secretPlaceholder.FindStringSubmatch(m)[1]
+			v, err := r.Lookup(name)
+			if err != nil && missing == nil {
+				missing = err
+			}
+			return v
+		})
+		if missing != nil {
+			errs = append(errs, fmt.Errorf(ui.Msg.SecretAliasSkipped, a.Name, missing))
+			continue
+		}
+		resolved = append(resolved, a)
+	}
+	return resolved, errs
+}
+
+// CheckSecretLevel refuses secret placeholders outside the user level. Pack and global
+// aliases are written by someone else, who could send the user's secrets anywhere.
+func CheckSecretLevel(a Alias) error {
+	if !secretUser(a) || !(a.Level == "global" || strings.HasPrefix(a.Level, PackLevelPrefix)) {
+		return nil
+	}
+	return fmt.Errorf(ui.Msg.SecretAliasRefused, a.Name, fmt.Errorf(ui.Msg.SecretNotUserLevel, a.Level))
+}
+
+// WithoutSecrets returns the aliases of a pack or global layer that use no secret
+// placeholders. The others are left out and returned as errors, so only user aliases
+// ever get a secret's value.
+func WithoutSecrets(aliases []Alias, level string) ([]Alias, []error) {
+	kept := make([]Alias, 0, len(aliases))
+	var errs []error
+	for _, a := range aliases {
+		if a.Disabled || !secretUser(a) {
+			kept = append(kept, a)
+			continue
+		}
+		if a.Level != "" {
+			level = a.Level
+		}
+		errs = append(errs, fmt.Errorf(ui.Msg.SecretAliasSkipped, a.Name, fmt.Errorf(ui.Msg.SecretNotUserLevel, level)))
+	}
+	return kept, errs
+}
+
+// secretUser reports whether the command of a refers to a secret.
+func secretUser(a Alias) bool {
+	return secretPlaceholder.MatchString(a.Command)
+}
+
+// ReadSecretFile reads the secrets in path. A missing file holds no secrets; a file that
+// others can read or write is refused, since its values end up in every shell.
+func ReadSecretFile(path string) (map[string]string, error) {
+	info, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if info.Mode().Perm()&0077 != 0 {
+		return nil, fmt.Errorf(ui.Msg.SecretFileTooOpen, path, info.Mode().Perm())
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+		if name, value, ok := strings.Cut(line, "="); ok && IsSecretName(strings.TrimSpace(name)) {
+			values[strings.TrimSpace(name)] = value
+		}
+	}
+	return values, scanner.Err()
+}
+
+// SecretsPath returns the path of the secret file in the user config.
+func (pm *PersistManager) SecretsPath() string {
+	return filepath.Join(pm.UserConfigPath, SECRETS_FILE)
+}
+
+// SetSecret stores value as the secret called name in the secret file, replacing an
+// existing value, or removes the secret when value is nil. The file is written with
+// mode 0600 and reports whether name was in it.
+func (pm *PersistManager) SetSecret(name string, value *string) (bool, error) {
+	values, err := ReadSecretFile(pm.SecretsPath())
+	if err != nil {
+		return false, err
+	}
+	if values == nil {
+		values = make(map[string]string)
+	}
+	_, existed := values[name]
+	if value == nil {
+		if !existed {
+			return false, nil
+		}
+		delete(values, name)
+	} else {
+		values[name] = *value
+	}
+
+	var sb strings.Builder
+	sb.WriteString("# QuickAlias secrets, used by ${secret:name} in aliases. Keep this file private.\n")
+	for _, n := range SortedSecretNames(values) {
+		sb.WriteString(n + "=" + values[n] + "\n")
+	}
+	if err := pm.MkdirUser(pm.UserConfigPath); err != nil {
+		return false, err
+	}
+	path := pm.SecretsPath()
+	tmp := path + ".tmp"
+	os.Remove(tmp)
+	if err := os.WriteFile(tmp, []byte(sb.String()), 0600); err != nil {
+		return false, err
+	}
+	pm.ChownUser(tmp)
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return false, err
+	}
+	return existed, nil
+}
+
+// SortedSecretNames returns the names of values in order.
+func SortedSecretNames(values map[string]string) []string {
+	names := make([]string, 0, len(values))
+	for n := range values {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,63 @@
+package alias
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSecretResolverLearn(t *testing.T) {
+	tests := []struct {
+		template, command string
+		match             bool
+		values            map[string]string
+	}{
+		{"curl -H 'Authorization: Bearer ${secret:gh}' api", "curl -H 'Authorization: Bearer s3cr3t-t0ken' api", true, map[string]string{"gh": "s3cr3t-t0ken"}},
+		{"login ${secret:user} ${secret:pin}", "login alice 1234", true, map[string]string{"user": "alice", "pin": "1234"}},
+		{"echo ${secret:a} ${secret:a}", "echo x y", false, nil},
+		{"echo ${secret:a} ${secret:a}", "echo x x", true, map[string]string{"a": "x"}},
+		{"curl ${secret:gh}", "wget token", false, nil},
+		{"ls", "ls", true, nil},
+		{"ls", "ls -l", false, nil},
+	}
+	for _, tt := range tests {
+		r := NewSecretResolver(filepath.Join(t.TempDir(), SECRETS_FILE))
+		if got := r.Learn(tt.template, tt.command); got != tt.match {
+			t.Errorf("Learn(%q, %q) = %v, want %v", tt.template, tt.command, got, tt.match)
+			continue
+		}
+		for name, want := range tt.values {
+			if r.resolved[name] != want {
+				t.Errorf("Learn(%q, %q): %s = %q, want %q", tt.template, tt.command, name, r.resolved[name], want)
+			}
+		}
+	}
+}
+
+func TestSecretResolverConcealAndShortSecret(t *testing.T) {
+	path := filepath.Join(t.TempDir(), SECRETS_FILE)
+	if err := os.WriteFile(path, []byte("gh=ghp_long_value\npin=1234\nempty=\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	r := NewSecretResolver(path)
+	r.Learn("login ${secret:user}", "login bob")
+
+	tests := []struct {
+		command, concealed, short string
+	}{
+		{"curl -u x:ghp_long_value api", "curl -u x:${secret:gh} api", ""},
+		{"unlock 1234", "unlock 1234", "pin"},
+		{"ssh bob@host", "ssh bob@host", "user"},
+		{"ls -l", "ls -l", ""},
+		{"echo ${secret:pin}", "echo ${secret:pin}", ""},
+	}
+	for _, tt := range tests {
+		concealed := r.Conceal(tt.command)
+		if concealed != tt.concealed {
+			t.Errorf("Conceal(%q) = %q, want %q", tt.command, concealed, tt.concealed)
+		}
+		if short, _ := r.ShortSecret(concealed); short != tt.short {
+			t.Errorf("ShortSecret(%q) = %q, want %q", concealed, short, tt.short)
+		}
+	}
+}
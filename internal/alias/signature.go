@@ -0,0 +1,124 @@
+package alias
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"quickalias/internal/ui"
+)
+
+// PublicKey is a key trusted to sign packs: a raw ed25519 key, or a minisign key, which
+// also carries the key ID that minisign signatures name.
+type PublicKey struct {
+	Key   ed25519.PublicKey
+	KeyID []byte // Minisign key ID; nil for raw ed25519 keys.
+}
+
+// ID returns a short identifier of the key for messages: the minisign key ID, or the
+// start of a raw key, in hex.
+func (k PublicKey) ID() string {
+	if k.KeyID != nil {
+		return strings.ToUpper(hex.EncodeToString(reverse(k.KeyID)))
+	}
+	return hex.EncodeToString(k.Key[:8])
+}
+
+// ParsePublicKey parses a base64 public key: 32 bytes for a raw ed25519 key, or the
+// second line of a minisign public key file ("Ed", 8-byte key ID, 32-byte key).
+func ParsePublicKey(text string) (PublicKey, error) {
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(text))
+	switch {
+	case err != nil:
+	case len(data) == ed25519.PublicKeySize:
+		return PublicKey{Key: ed25519.PublicKey(data)}, nil
+	case len(data) == 2+8+ed25519.PublicKeySize && string(data[:2]) == "Ed":
+		return PublicKey{Key: ed25519.PublicKey(data[10:]), KeyID: data[2:10]}, nil
+	}
+	return PublicKey{}, fmt.Errorf(ui.Msg.PackInvalidPublicKey, text)
+}
+
+// VerifySignature checks that signature signs data with one of keys and returns the key
+// that did. A signature is either a minisign signature file (legacy or prehashed) or
+// a raw 64-byte ed25519 signature, optionally base64-encoded.
+func VerifySignature(data, signature []byte, keys []PublicKey) (PublicKey, error) {
+	if len(keys) == 0 {
+		return PublicKey{}, errors.New(ui.Msg.PackNoTrustedKeys)
+	}
+	if bytes.HasPrefix(signature, []byte("untrusted comment:")) {
+		return verifyMinisign(data, signature, keys)
+	}
+
+	raw := signature
+	if len(raw) != ed25519.SignatureSize {
+		decoded, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(signature)))
+		if err != nil || len(decoded) != ed25519.SignatureSize {
+			return PublicKey{}, errors.New(ui.Msg.PackSignatureMalformed)
+		}
+		raw = decoded
+	}
+	for _, k := range keys {
+		if ed25519.Verify(k.Key, data, raw) {
+			return k, nil
+		}
+	}
+	return PublicKey{}, errors.New(ui.Msg.PackSignatureInvalid)
+}
+
+// verifyMinisign checks a minisign signature file: the signature line names the algorithm
+// ("Ed" signs the data, "ED" its BLAKE2b-512 digest) and the key ID, and the global
+// signature covers the signature and the trusted comment.
+func verifyMinisign(data, signature []byte, keys []PublicKey) (PublicKey, error) {
+	lines := strings.Split(strings.ReplaceAll(string(signature), "\r\n", "\n"), "\n")
+	if len(lines) < 4 || !strings.HasPrefix(lines[2], "trusted comment: ") {
+		return PublicKey{}, errors.New(ui.Msg.PackSignatureMalformed)
+	}
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(lines[1]))
+	if err != nil || len(sig) != 2+8+ed25519.SignatureSize {
+		return PublicKey{}, errors.New(ui.Msg.PackSignatureMalformed)
+	}
+	global, err := base64.StdEncoding.DecodeString(strings.TrimSpace(lines[3]))
+	if err != nil || len(global) != ed25519.SignatureSize {
+		return PublicKey{}, errors.New(ui.Msg.PackSignatureMalformed)
+	}
+
+	algorithm, keyID, sig := string(sig[:2]), sig[2:10], sig[10:]
+	message := data
+	switch algorithm {
+	case "Ed":
+	case "ED":
+		digest := blake2b512(data)
+		message = digest[:]
+	default:
+		return PublicKey{}, errors.New(ui.Msg.PackSignatureMalformed)
+	}
+
+	comment := strings.TrimPrefix(lines[2], "trusted comment: ")
+	known := false
+	for _, k := range keys {
+		if !bytes.Equal(k.KeyID, keyID) {
+			continue
+		}
+		known = true
+		if ed25519.Verify(k.Key, message, sig) && ed25519.Verify(k.Key, append(append([]byte{}, sig...), comment...), global) {
+			return k, nil
+		}
+	}
+	if known {
+		return PublicKey{}, errors.New(ui.Msg.PackSignatureInvalid)
+	}
+	return PublicKey{}, fmt.Errorf(ui.Msg.PackSignatureUnknownKey, strings.ToUpper(hex.EncodeToString(reverse(keyID))))
+}
+
+// reverse returns b in reverse order; minisign shows key IDs as little-endian numbers.
+func reverse(b []byte) []byte {
+	r := make([]byte, len(b))
+	for i := range b {
+		r[len(b)-1-i] = b[i]
+	}
+	return r
+}
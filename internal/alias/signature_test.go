@@ -0,0 +1,122 @@
+package alias
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"quickalias/internal/ui"
+)
+
+// The fixtures in testdata were signed in the minisign format with OpenSSL's ed25519 and
+// Python's hashlib BLAKE2b, independently of this package, with the key derived from
+// testSeed and the key ID in minisign.pub.
+const fixtureKeyID = "46907B5D2E1C3F8A"
+
+// testSeed returns the ed25519 seed of the fixture key: the bytes 1 to 32.
+func testSeed() []byte {
+	seed := make([]byte, ed25519.SeedSize)
+	for i := range seed {
+		seed[i] = byte(i + 1)
+	}
+	return seed
+}
+
+// readFixture returns the content of a file in testdata.
+func readFixture(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+// fixtureKey parses the public key in testdata/minisign.pub.
+func fixtureKey(t *testing.T) PublicKey {
+	t.Helper()
+	lines := strings.Split(string(readFixture(t, "minisign.pub")), "\n")
+	key, err := ParsePublicKey(lines[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func TestParsePublicKey(t *testing.T) {
+	key := fixtureKey(t)
+	if key.ID() != fixtureKeyID {
+		t.Errorf("ID() = %s, want %s", key.ID(), fixtureKeyID)
+	}
+	if want := ed25519.NewKeyFromSeed(testSeed()).Public().(ed25519.PublicKey); !key.Key.Equal(want) {
+		t.Errorf("key = %x, want %x", key.Key, want)
+	}
+	for _, text := range []string{"", "not base64", "RWSKPxwu"} {
+		if _, err := ParsePublicKey(text); err == nil {
+			t.Errorf("ParsePublicKey(%q) succeeded", text)
+		}
+	}
+}
+
+func TestVerifyMinisign(t *testing.T) {
+	data := readFixture(t, "pack.json")
+	key := fixtureKey(t)
+	for _, name := range []string{"pack.json.minisig", "pack.json.legacy.minisig"} {
+		t.Run(name, func(t *testing.T) {
+			signature := readFixture(t, name)
+			got, err := VerifySignature(data, signature, []PublicKey{key})
+			if err != nil {
+				t.Fatalf("VerifySignature: %v", err)
+			}
+			if got.ID() != fixtureKeyID {
+				t.Errorf("signed by %s, want %s", got.ID(), fixtureKeyID)
+			}
+
+			tampered := append([]byte{}, data...)
+			tampered[len(tampered)-3] ^= 1
+			if _, err := VerifySignature(tampered, signature, []PublicKey{key}); err == nil || err.Error() != ui.Msg.PackSignatureInvalid {
+				t.Errorf("tampered data: err = %v, want %q", err, ui.Msg.PackSignatureInvalid)
+			}
+
+			comment := strings.Replace(string(signature), "timestamp:1792310400", "timestamp:1792310401", 1)
+			if _, err := VerifySignature(data, []byte(comment), []PublicKey{key}); err == nil || err.Error() != ui.Msg.PackSignatureInvalid {
+				t.Errorf("tampered trusted comment: err = %v, want %q", err, ui.Msg.PackSignatureInvalid)
+			}
+		})
+	}
+}
+
+func TestVerifyMinisignUnknownKey(t *testing.T) {
+	data, signature := readFixture(t, "pack.json"), readFixture(t, "pack.json.minisig")
+	key := fixtureKey(t)
+	other := PublicKey{Key: key.Key, KeyID: []byte{1, 2, 3, 4, 5, 6, 7, 8}}
+
+	_, err := VerifySignature(data, signature, []PublicKey{other})
+	if want := fmt.Sprintf(ui.Msg.PackSignatureUnknownKey, fixtureKeyID); err == nil || err.Error() != want {
+		t.Errorf("err = %v, want %q", err, want)
+	}
+	if _, err := VerifySignature(data, signature, nil); err == nil || err.Error() != ui.Msg.PackNoTrustedKeys {
+		t.Errorf("no keys: err = %v, want %q", err, ui.Msg.PackNoTrustedKeys)
+	}
+}
+
+func TestVerifyRawSignature(t *testing.T) {
+	data := readFixture(t, "pack.json")
+	private := ed25519.NewKeyFromSeed(testSeed())
+	key := PublicKey{Key: private.Public().(ed25519.PublicKey)}
+	signature := ed25519.Sign(private, data)
+
+	if _, err := VerifySignature(data, signature, []PublicKey{key}); err != nil {
+		t.Errorf("raw signature: %v", err)
+	}
+	if _, err := VerifySignature(data, []byte(base64.StdEncoding.EncodeToString(signature)+"\n"), []PublicKey{key}); err != nil {
+		t.Errorf("base64 signature: %v", err)
+	}
+	if _, err := VerifySignature(data, []byte("garbage"), []PublicKey{key}); err == nil || err.Error() != ui.Msg.PackSignatureMalformed {
+		t.Errorf("malformed signature: err = %v, want %q", err, ui.Msg.PackSignatureMalformed)
+	}
+}
@@ -0,0 +1,172 @@
+package alias
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// storeFiles are the names an alias store may have, in the order they are looked for.
+// The extension selects the format; without any of them, aliases are stored as JSON.
+var storeFiles = []string{ALIASES_FILE, "aliases.yaml", "aliases.yml", "aliases.toml"}
+
+// StorePath returns the alias store in dir: the first of aliases.json, aliases.yaml,
+// aliases.yml and aliases.toml that exists, or aliases.json.
+func StorePath(dir string) string {
+	for _, name := range storeFiles {
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return filepath.Join(dir, ALIASES_FILE)
+}
+
+// storeFormat returns the format of an alias file from its extension: yaml, toml or json.
+func storeFormat(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return ExportYAML
+	case ".toml":
+		return ExportTOML
+	}
+	return ExportJSON
+}
+
+// DecodeAliases parses an alias list in the format given by the extension of path.
+// YAML and TOML files hold the same fields as JSON; they are either a list of aliases
+// or have the list under "aliases" (TOML: [[aliases]] tables).
+func DecodeAliases(path string, data []byte) ([]Alias, error) {
+	var tree interface{}
+	var err error
+	switch storeFormat(path) {
+	case ExportYAML:
+		tree, err = parseYAML(data)
+	case ExportTOML:
+		tree, err = parseTOML(data)
+	default:
+		var aliases []Alias
+		if err := json.Unmarshal(data, &aliases); err != nil {
+			return nil, err
+		}
+		return aliases, nil
+	}
+	if err != nil || tree == nil {
+		return nil, err
+	}
+	return aliasesFromTree(tree)
+}
+
+// EncodeAliases renders aliases in the format of the file at path. previous is the file's
+// current content: for YAML and TOML its leading comments and the comments above each
+// alias, matched by name, are carried over. Comments elsewhere are not kept. YAML and TOML
+// leave out an empty creation date or level rather than writing empty values.
+func EncodeAliases(path string, aliases []Alias, previous []byte) ([]byte, error) {
+	format := storeFormat(path)
+	if format == ExportJSON {
+		return json.MarshalIndent(aliases, "", "  ") // Use 2 spaces for indentation
+	}
+
+	header, comments := storeComments(path, previous)
+	var sb strings.Builder
+	for _, line := range header {
+		sb.WriteString(line + "\n")
+	}
+	for i, a := range aliases {
+		v, err := orderedJSON(a)
+		if err != nil {
+			return nil, err
+		}
+		if (i > 0 || len(header) > 0) && (format == ExportTOML || len(comments[a.Name]) > 0) {
+			sb.WriteString("\n")
+		}
+		for _, line := range comments[a.Name] {
+			sb.WriteString(line + "\n")
+		}
+		fields := omitEmpty(v.([]field), "created", "level")
+		if format == ExportYAML {
+			yamlListItem(&sb, fields)
+		} else {
+			writeTOMLTable(&sb, "aliases", fields)
+		}
+	}
+	return []byte(sb.String()), nil
+}
+
+// omitEmpty returns fields without the given keys when their value is an empty string.
+func omitEmpty(fields []field, keys ...string) []field {
+	kept := make([]field, 0, len(fields))
+	for _, f := range fields {
+		if f.value == "" && slices.Contains(keys, f.key) {
+			continue
+		}
+		kept = append(kept, f)
+	}
+	return kept
+}
+
+// storeComments collects the comments of a YAML or TOML alias file: those before the
+// first alias that are separated from it by a blank line form the header; the others
+// belong to the alias below them.
+func storeComments(path string, data []byte) ([]string, map[string][]string) {
+	comments := make(map[string][]string)
+	aliases, err := DecodeAliases(path, data)
+	if err != nil {
+		return nil, comments
+	}
+
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	itemIndent := -1
+	var header, pending []string
+	detached := 0 // Number of pending comment lines followed by a blank line.
+	item := 0
+	for _, line := range lines {
+		text := strings.TrimLeft(line, " \t")
+		indent := len(line) - len(text)
+		var starts bool
+		if storeFormat(path) == ExportTOML {
+			starts = strings.HasPrefix(text, "[[") && len(splitTOMLKey(strings.Trim(strings.TrimSpace(stripTOMLComment(text)), "[]"))) == 1
+		} else if isYAMLSequenceItem(text) && (itemIndent < 0 || indent == itemIndent) {
+			itemIndent, starts = indent, true
+		}
+
+		switch {
+		case strings.HasPrefix(text, "#"):
+			pending = append(pending, text) // Aliases are written at the start of the line.
+		case text == "":
+			if len(pending) > 0 {
+				pending = append(pending, "") // Blank lines between comments are kept.
+			}
+			detached = len(pending)
+		case starts && item < len(aliases):
+			if item == 0 {
+				// Comments above an "aliases:" key are in the header already.
+				header, pending = trimBlank(append(header, pending[:detached]...)), pending[detached:]
+			}
+			if pending = trimBlank(pending); len(pending) > 0 {
+				comments[aliases[item].Name] = pending
+			}
+			item++
+			pending, detached = nil, 0
+		default:
+			if item == 0 {
+				header = trimBlank(append(header, pending...))
+			}
+			pending, detached = nil, 0
+		}
+	}
+	if item == 0 {
+		header = trimBlank(append(header, pending...)) // A store without aliases is all header.
+	}
+	return header, comments
+}
+
+// trimBlank drops the empty lines at both ends of lines.
+func trimBlank(lines []string) []string {
+	for len(lines) > 0 && lines[0] == "" {
+		lines = lines[1:]
+	}
+	return lines[:len(lines)-countTrailingBlank(lines)]
+}
@@ -0,0 +1,153 @@
+package alias
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDecodeAliasesFlowMappings(t *testing.T) {
+	tests := []struct {
+		path, data string
+	}{
+		{"a.yaml", "- {name: ll, command: ls -la}\n"},
+		{"a.yaml", "aliases:\n  - {name: ll, command: ls -la}\n"},
+		{"a.yaml", "aliases: [{name: ll, command: ls -la}]\n"},
+		{"a.toml", "aliases = [{name = \"ll\", command = \"ls -la\"}]\n"},
+	}
+	for _, tt := range tests {
+		aliases, err := DecodeAliases(tt.path, []byte(tt.data))
+		if err != nil {
+			t.Errorf("DecodeAliases(%q): %v", tt.data, err)
+			continue
+		}
+		if want := []Alias{{Name: "ll", Command: "ls -la"}}; !reflect.DeepEqual(aliases, want) {
+			t.Errorf("DecodeAliases(%q) = %+v, want %+v", tt.data, aliases, want)
+		}
+	}
+}
+
+func TestDecodeAliasesRejectsBadEntries(t *testing.T) {
+	tests := []struct {
+		path, data, err string
+	}{
+		{"a.yaml", "- name: ll\n  command: ls\n- nmae: gs\n  command: git status\n", `line 3: unknown key "nmae"`},
+		{"a.yaml", "- name: ll\n  command: ls\n  tgas: [x]\n", `line 3: unknown key "tgas"`},
+		{"a.yaml", "- command: ls\n", "line 1: alias name cannot be empty"},
+		{"a.yaml", "- {name: 'x;rm -rf ~', command: ls}\n", "line 1: invalid alias name 'x;rm -rf ~'"},
+		{"a.yaml", "- name: ll\n  command: ls\n  conditions: {hots: [a]}\n", "line 1: "},
+		{"a.yaml", "- ls\n", "alias 1: expected a mapping"},
+		{"a.yaml", "name: ll\n", `no "aliases" list found`},
+		{"a.toml", "[[aliases]]\nname = \"ll\"\ncommand = \"ls\"\n\n[[aliases]]\nnmae = \"gs\"\n", `line 6: unknown key "nmae"`},
+		{"a.toml", "[[aliases]]\ncommand = \"ls\"\n", "line 2: alias name cannot be empty"},
+	}
+	for _, tt := range tests {
+		_, err := DecodeAliases(tt.path, []byte(tt.data))
+		if err == nil || !strings.HasPrefix(err.Error(), tt.err) {
+			t.Errorf("DecodeAliases(%q): err = %v, want %q", tt.data, err, tt.err)
+		}
+	}
+}
+
+func TestLoadAliasesRefusesToSaveOverInvalidNames(t *testing.T) {
+	dir := t.TempDir()
+	user := filepath.Join(dir, "user")
+	if err := os.MkdirAll(user, 0700); err != nil {
+		t.Fatal(err)
+	}
+	store := `[{"name": "x;id", "command": "ls"}, {"name": "ll", "command": "ls -l"}]`
+	if err := os.WriteFile(filepath.Join(user, ALIASES_FILE), []byte(store), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	var userAliases, globalAliases, packAliases []Alias
+	pm := NewPersistManager(user, filepath.Join(dir, "global"), &userAliases, &globalAliases, &packAliases)
+	pm.LoadAliases()
+	if len(userAliases) != 2 || userAliases[1].Level != "user" {
+		t.Fatalf("loaded %+v", userAliases)
+	}
+	if err := pm.SaveAliases("user", "%v", "%v", "%v"); err == nil {
+		t.Error("SaveAliases wrote over a store with an invalid name")
+	}
+	data, _ := os.ReadFile(filepath.Join(user, ALIASES_FILE))
+	if string(data) != store {
+		t.Errorf("store changed to %s", data)
+	}
+}
+
+func TestEncodeAliasesKeepsHeaderOfAliasesKey(t *testing.T) {
+	previous := []byte("# my aliases\n\naliases:\n  # list files\n  - name: ll\n    command: ls\n")
+	aliases, err := DecodeAliases("aliases.yaml", previous)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := EncodeAliases("aliases.yaml", aliases, previous)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "# my aliases\n\n# list files\n- name: ll\n  command: ls\n"; string(data) != want {
+		t.Errorf("EncodeAliases = %q, want %q", data, want)
+	}
+}
+
+func TestEncodeDecodeRoundTrip(t *testing.T) {
+	aliases := []Alias{
+		{Name: "ll", Command: "ls -la", Created: "2026-01-02T03:04:05Z", Level: "user"},
+		{Name: "q", Command: `echo "a" 'b' # not a comment`, Tags: []string{"yes", "null", "- x"}},
+		{Name: "multi", Command: "echo a\n  echo b\n", Description: "key: value"},
+		{Name: "pad", Command: "  ls\t ", Category: "ünïcödé"},
+		{Name: "esc", Command: `printf '\t%s\n' "$1" \`, Disabled: true, AllowShadow: true},
+		{Name: "k", Command: "kubectl", Conditions: &Conditions{Hosts: []string{"web-*"}, Env: []string{"KUBECONFIG=~/.kube/x"}}},
+	}
+	tests := []struct {
+		path, previous string
+	}{
+		{"aliases.yaml", "# my aliases\n\n# list files\n# in long form\n- name: ll\n  command: ls\n\n# kubectl\n- name: k\n  command: k\n"},
+		{"aliases.toml", "# my aliases\n\n# list files\n# in long form\n[[aliases]]\nname = \"ll\"\ncommand = \"ls\"\n\n# kubectl\n[[aliases]]\nname = \"k\"\ncommand = \"k\"\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			data, err := EncodeAliases(tt.path, aliases, []byte(tt.previous))
+			if err != nil {
+				t.Fatalf("EncodeAliases: %v", err)
+			}
+			decoded, err := DecodeAliases(tt.path, data)
+			if err != nil {
+				t.Fatalf("DecodeAliases: %v\n%s", err, data)
+			}
+			if !reflect.DeepEqual(decoded, aliases) {
+				t.Errorf("decoded %+v\nwant    %+v\nfrom\n%s", decoded, aliases, data)
+			}
+			for _, comment := range []string{"# my aliases\n\n", "# list files\n# in long form\n", "# kubectl\n"} {
+				if !strings.Contains(string(data), comment) {
+					t.Errorf("comment %q lost:\n%s", comment, data)
+				}
+			}
+			if !strings.HasPrefix(string(data), "# my aliases\n") {
+				t.Errorf("header moved:\n%s", data)
+			}
+
+			again, err := EncodeAliases(tt.path, decoded, data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(again) != string(data) {
+				t.Errorf("second encoding differs:\n%s\nfirst:\n%s", again, data)
+			}
+		})
+	}
+}
+
+func TestEncodeAliasesWithoutAliasesKeepsHeader(t *testing.T) {
+	for _, path := range []string{"aliases.yaml", "aliases.toml"} {
+		data, err := EncodeAliases(path, nil, []byte("# my aliases\n"))
+		if err != nil || string(data) != "# my aliases\n" {
+			t.Errorf("EncodeAliases(%s) = %q, %v", path, data, err)
+		}
+		if aliases, err := DecodeAliases(path, data); err != nil || len(aliases) != 0 {
+			t.Errorf("DecodeAliases(%s) = %+v, %v", path, aliases, err)
+		}
+	}
+}
@@ -0,0 +1,318 @@
+package alias
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// The TOML reader covers what alias files need: tables, arrays of tables, dotted keys,
+// basic, literal and multi-line strings, arrays and inline tables, and comments. Dates
+// and numbers are read as strings.
+
+// tomlTable is a table whose keys keep their document order.
+type tomlTable struct {
+	keys   []string
+	values map[string]interface{} // Scalars, []interface{}, *tomlTable or []*tomlTable.
+	lines  map[string]int         // Line of each key set by a key = value line.
+	line   int                    // Line of the table's header, 0 for implicit tables.
+}
+
+func newTOMLTable() *tomlTable {
+	return &tomlTable{values: make(map[string]interface{}), lines: make(map[string]int)}
+}
+
+// set adds key, failing if it is already defined.
+func (t *tomlTable) set(key string, value interface{}) error {
+	if _, ok := t.values[key]; ok {
+		return fmt.Errorf("key %q is defined twice", key)
+	}
+	t.keys = append(t.keys, key)
+	t.values[key] = value
+	return nil
+}
+
+// table returns the sub-table at key, creating it if needed. For an array of tables it
+// returns the last element, as TOML does for [array.sub] headers.
+func (t *tomlTable) table(key string) (*tomlTable, error) {
+	switch v := t.values[key].(type) {
+	case nil:
+		sub := newTOMLTable()
+		return sub, t.set(key, sub)
+	case *tomlTable:
+		return v, nil
+	case []*tomlTable:
+		return v[len(v)-1], nil
+	}
+	return nil, fmt.Errorf("key %q is not a table", key)
+}
+
+// tree converts the table into []field, the form shared with the YAML reader.
+func (t *tomlTable) tree() []field {
+	fields := make([]field, 0, len(t.keys))
+	for _, key := range t.keys {
+		value := t.values[key]
+		switch v := value.(type) {
+		case *tomlTable:
+			value = v.tree()
+		case []*tomlTable:
+			tables := make([]interface{}, len(v))
+			for i, table := range v {
+				tables[i] = table.tree()
+			}
+			value = tables
+		}
+		line := t.lines[key]
+		if line == 0 {
+			line = t.line
+		}
+		fields = append(fields, field{key: key, value: value, line: line})
+	}
+	return fields
+}
+
+// parseTOML parses a TOML document into a tree of []field, []interface{} and scalars.
+func parseTOML(data []byte) (interface{}, error) {
+	root := newTOMLTable()
+	current := root
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	for n := 0; n < len(lines); n++ {
+		lineNumber := n + 1
+		text := strings.TrimSpace(lines[n])
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		errorf := func(format string, a ...interface{}) error {
+			return fmt.Errorf("line %d: %s", lineNumber, fmt.Sprintf(format, a...))
+		}
+
+		if strings.HasPrefix(text, "[") {
+			array := strings.HasPrefix(text, "[[")
+			end := strings.Index(text, "]")
+			if end < 0 || array && !strings.HasPrefix(text[end:], "]]") {
+				return nil, errorf("unterminated table header")
+			}
+			header := text[1:end]
+			rest := text[end+1:]
+			if array {
+				header, rest = text[2:end], text[end+2:]
+			}
+			if !trailingComment(rest) {
+				return nil, errorf("unexpected %q after table header", rest)
+			}
+			path := splitTOMLKey(header)
+			parent := root
+			for _, key := range path[:len(path)-1] {
+				var err error
+				if parent, err = parent.table(key); err != nil {
+					return nil, errorf("%v", err)
+				}
+			}
+			last := path[len(path)-1]
+			if !array {
+				var err error
+				if current, err = parent.table(last); err != nil {
+					return nil, errorf("%v", err)
+				}
+				continue
+			}
+			current = newTOMLTable()
+			current.line = lineNumber
+			switch tables := parent.values[last].(type) {
+			case nil:
+				parent.set(last, []*tomlTable{current})
+			case []*tomlTable:
+				parent.values[last] = append(tables, current)
+			default:
+				return nil, errorf("key %q is not an array of tables", last)
+			}
+			continue
+		}
+
+		eq := tomlKeyEnd(text)
+		if eq < 0 {
+			return nil, errorf("expected key = value")
+		}
+		path := splitTOMLKey(text[:eq])
+		valueText := strings.TrimSpace(text[eq+1:])
+
+		var value interface{}
+		var end int
+		var err error
+		if strings.HasPrefix(valueText, `"""`) || strings.HasPrefix(valueText, "'''") {
+			value, valueText, n, err = multilineTOMLString(valueText, lines, n)
+		} else {
+			valueText = strings.TrimSpace(stripTOMLComment(valueText))
+			for {
+				value, end, err = flowValue(valueText, 0, false)
+				if errors.Is(err, errUnterminatedValue) && n+1 < len(lines) {
+					n++
+					valueText += "\n" + stripTOMLComment(lines[n])
+					continue
+				}
+				break
+			}
+			valueText = valueText[min(end, len(valueText)):]
+		}
+		if err == nil && !trailingComment(valueText) {
+			err = fmt.Errorf("unexpected %q after value", strings.TrimSpace(valueText))
+		}
+		if err != nil {
+			return nil, errorf("%v", err)
+		}
+
+		table := current
+		for _, key := range path[:len(path)-1] {
+			if table, err = table.table(key); err != nil {
+				return nil, errorf("%v", err)
+			}
+		}
+		setLine(value, lineNumber)
+		if err := table.set(path[len(path)-1], value); err != nil {
+			return nil, errorf("%v", err)
+		}
+		table.lines[path[len(path)-1]] = lineNumber
+	}
+	return root.tree(), nil
+}
+
+// multilineTOMLString reads a """ or ”' string that may span several lines starting at
+// lines[n]. It returns the value, the text after it and the index of its last line.
+func multilineTOMLString(text string, lines []string, n int) (string, string, int, error) {
+	delimiter := text[:3]
+	text = strings.TrimPrefix(text[3:], "\n")
+	if text == "" && n+1 < len(lines) {
+		n++
+		text = lines[n] // A newline right after the opening delimiter is dropped.
+	}
+	for {
+		if end := strings.Index(text, delimiter); end >= 0 {
+			raw, rest := text[:end], text[end+3:]
+			if delimiter == "'''" {
+				return raw, rest, n, nil
+			}
+			value, err := unescapeTOML(raw)
+			return value, rest, n, err
+		}
+		if n+1 >= len(lines) {
+			return "", "", n, errors.New("unterminated multi-line string")
+		}
+		n++
+		text += "\n" + lines[n]
+	}
+}
+
+// unescapeTOML resolves the escapes of a basic string, including a backslash at the
+// end of a line, which joins it to the next non-blank text.
+func unescapeTOML(s string) (string, error) {
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' {
+			sb.WriteByte(s[i])
+			continue
+		}
+		if i+1 >= len(s) {
+			return "", errors.New("invalid escape at end of string")
+		}
+		i++
+		switch c := s[i]; c {
+		case 'b':
+			sb.WriteByte('\b')
+		case 't':
+			sb.WriteByte('\t')
+		case 'n':
+			sb.WriteByte('\n')
+		case 'f':
+			sb.WriteByte('\f')
+		case 'r':
+			sb.WriteByte('\r')
+		case 'e':
+			sb.WriteByte(0x1b)
+		case '"', '\\':
+			sb.WriteByte(c)
+		case 'u', 'U':
+			size := 4
+			if c == 'U' {
+				size = 8
+			}
+			if i+size >= len(s) {
+				return "", fmt.Errorf("invalid escape \\%c", c)
+			}
+			r, err := strconv.ParseUint(s[i+1:i+1+size], 16, 32)
+			if err != nil {
+				return "", fmt.Errorf("invalid escape \\%c%s", c, s[i+1:i+1+size])
+			}
+			sb.WriteRune(rune(r))
+			i += size
+		case ' ', '\t', '\n', '\r':
+			for i+1 < len(s) && strings.ContainsRune(" \t\r\n", rune(s[i+1])) {
+				i++
+			}
+		default:
+			return "", fmt.Errorf("invalid escape \\%c", c)
+		}
+	}
+	return sb.String(), nil
+}
+
+// tomlKeyEnd returns the position of the = that ends the key of a key/value line, or -1.
+func tomlKeyEnd(text string) int {
+	var quote byte
+	for i := 0; i < len(text); i++ {
+		switch c := text[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '=':
+			return i
+		}
+	}
+	return -1
+}
+
+// splitTOMLKey splits a dotted key such as aliases."my.key" into its parts.
+func splitTOMLKey(key string) []string {
+	var parts []string
+	var sb strings.Builder
+	var quote byte
+	for i := 0; i < len(key); i++ {
+		switch c := key[i]; {
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote != 0:
+			sb.WriteByte(c)
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '.':
+			parts = append(parts, strings.TrimSpace(sb.String()))
+			sb.Reset()
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return append(parts, strings.TrimSpace(sb.String()))
+}
+
+// stripTOMLComment removes a # comment that is not inside a string from line.
+func stripTOMLComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case quote != 0 && c == '\\' && quote == '"':
+			i++
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#':
+			return line[:i]
+		}
+	}
+	return line
+}
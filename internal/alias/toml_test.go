@@ -0,0 +1,71 @@
+package alias
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDecodeTOML(t *testing.T) {
+	tests := []struct {
+		name, data string
+		want       []Alias
+	}{
+		{"array of tables", "# mine\n[[aliases]]\nname = \"ll\"\ncommand = \"ls -la\" # long\n\n[[aliases]]\nname = \"gs\"\ncommand = 'git status'\n",
+			[]Alias{{Name: "ll", Command: "ls -la"}, {Name: "gs", Command: "git status"}}},
+		{"basic string escapes", "[[aliases]]\nname = \"x\"\ncommand = \"printf '\\\\t%s\\\\n' \\\"\\u00e7\\\" # \\t\"\n",
+			[]Alias{{Name: "x", Command: "printf '\\t%s\\n' \"ç\" # \t"}}},
+		{"literal string", "[[aliases]]\nname = \"x\"\ncommand = 'grep \"a\\b\"'\n",
+			[]Alias{{Name: "x", Command: `grep "a\b"`}}},
+		{"multi-line basic string", "[[aliases]]\nname = \"x\"\ncommand = \"\"\"\necho a\necho \\\n    b\"\"\"\n",
+			[]Alias{{Name: "x", Command: "echo a\necho b"}}},
+		{"multi-line literal string", "[[aliases]]\nname = \"x\"\ncommand = '''\necho \\n\n  b\n'''\n",
+			[]Alias{{Name: "x", Command: "echo \\n\n  b\n"}}},
+		{"arrays and booleans", "[[aliases]]\nname = \"k\"\ncommand = \"kubectl\"\ntags = [\"k8s\",\n  \"ops\", # ops\n]\ndisabled = true\n",
+			[]Alias{{Name: "k", Command: "kubectl", Tags: []string{"k8s", "ops"}, Disabled: true}}},
+		{"inline table", "[[aliases]]\nname = \"k\"\ncommand = \"kubectl\"\nconditions = {hosts = [\"web-*\"], os = [\"linux\"]}\n",
+			[]Alias{{Name: "k", Command: "kubectl", Conditions: &Conditions{Hosts: []string{"web-*"}, OS: []string{"linux"}}}}},
+		{"dotted key", "[[aliases]]\nname = \"k\"\ncommand = \"kubectl\"\nconditions.requires = [\"kubectl\"]\n",
+			[]Alias{{Name: "k", Command: "kubectl", Conditions: &Conditions{Requires: []string{"kubectl"}}}}},
+		{"sub-table of an array table", "[[aliases]]\nname = \"k\"\ncommand = \"kubectl\"\n\n[aliases.conditions]\nshells = [\"zsh\"]\n\n[[aliases]]\nname = \"ll\"\ncommand = \"ls\"\n",
+			[]Alias{{Name: "k", Command: "kubectl", Conditions: &Conditions{Shells: []string{"zsh"}}}, {Name: "ll", Command: "ls"}}},
+		{"empty document", "# nothing\n", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeAliases("aliases.toml", []byte(tt.data))
+			if err != nil {
+				t.Fatalf("DecodeAliases: %v", err)
+			}
+			if len(got) == 0 && len(tt.want) == 0 {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got  %+v\nwant %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeTOMLErrors(t *testing.T) {
+	tests := []struct {
+		name, data, err string
+	}{
+		{"duplicate key", "[[aliases]]\nname = \"ll\"\nname = \"gs\"\n", `line 3: key "name" is defined twice`},
+		{"unterminated string", "[[aliases]]\nname = \"ll\ncommand = \"ls\"\n", "line 2: "},
+		{"unterminated multi-line string", "[[aliases]]\nname = \"x\"\ncommand = \"\"\"ls\n", "line 3: unterminated multi-line string"},
+		{"invalid escape", "[[aliases]]\nname = \"x\"\ncommand = \"a\\qb\"\n", `line 3: invalid escape \q`},
+		{"unterminated table header", "[[aliases\n", "line 1: unterminated table header"},
+		{"missing value", "[[aliases]]\nname\n", "line 2: expected key = value"},
+		{"text after value", "[[aliases]]\nname = \"ll\" x\n", `line 2: unexpected "x" after value`},
+		{"table over a value", "aliases = 1\n[aliases.conditions]\n", `line 2: key "aliases" is not a table`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := DecodeAliases("aliases.toml", []byte(tt.data))
+			if err == nil || !strings.HasPrefix(err.Error(), tt.err) {
+				t.Errorf("err = %v, want %q", err, tt.err)
+			}
+		})
+	}
+}
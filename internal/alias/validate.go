@@ -0,0 +1,138 @@
+package alias
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"quickalias/internal/ui"
+)
+
+// Shell reserved words that cannot be used as alias names. An alias named after
+// one of them either never expands or breaks every script that uses the keyword.
+var reservedWords = map[string][]string{
+	"bash": {"!", "[[", "]]", "{", "}", "case", "coproc", "do", "done", "elif", "else", "esac", "fi", "for", "function", "if", "in", "select", "then", "time", "until", "while"},
+	"zsh":  {"!", "[[", "]]", "{", "}", "case", "coproc", "do", "done", "elif", "else", "end", "esac", "fi", "for", "foreach", "function", "if", "in", "nocorrect", "repeat", "select", "then", "time", "until", "while"},
+	"fish": {"and", "begin", "break", "builtin", "case", "command", "continue", "else", "end", "exec", "for", "function", "if", "not", "or", "return", "switch", "time", "while"},
+}
+
+// forbiddenNameChars are characters that are never valid in an alias name: quoting,
+// expansion and redirection characters, "=" (which separates name and value) and "/".
+const forbiddenNameChars = "'\"`$\\=/;&|<>()"
+
+// fishForbiddenNameChars are additionally rejected for fish, where aliases become functions.
+const fishForbiddenNameChars = "*?[]{}~#%"
+
+// ValidateName checks that name can be used as an alias in shellType.
+// An empty shellType validates against every supported shell, which is what
+// global aliases need since they are loaded by users of any shell.
+func ValidateName(name, shellType string) error {
+	if name == "" {
+		return fmt.Errorf(ui.Msg.InvalidAliasNameEmpty)
+	}
+	for _, r := range name {
+		switch {
+		case unicode.IsSpace(r) || unicode.IsControl(r):
+			return fmt.Errorf(ui.Msg.InvalidAliasNameWhitespace, name)
+		case strings.ContainsRune(forbiddenNameChars, r):
+			return fmt.Errorf(ui.Msg.InvalidAliasNameChar, name, string(r))
+		case (shellType == "" || shellType == "fish") && strings.ContainsRune(fishForbiddenNameChars, r):
+			return fmt.Errorf(ui.Msg.InvalidAliasNameChar, name, string(r))
+		}
+	}
+	if strings.HasPrefix(name, "-") {
+		return fmt.Errorf(ui.Msg.InvalidAliasNameDash, name)
+	}
+
+	for _, shell := range []string{"bash", "zsh", "fish"} {
+		if shellType != "" && shell != shellType {
+			continue
+		}
+		for _, word := range reservedWords[shell] {
+			if name == word {
+				return fmt.Errorf(ui.Msg.InvalidAliasNameReserved, name, shell)
+			}
+		}
+	}
+	return nil
+}
+
+// CheckCommand returns warnings for alias commands that are probably mistakes,
+// such as empty commands, unbalanced quotes or secrets written into the command. Warnings do not prevent saving.
+func CheckCommand(command string) []string {
+	var warnings []string
+	if strings.TrimSpace(command) == "" {
+		return append(warnings, ui.Msg.WarningCommandEmpty)
+	}
+
+	var inSingle, inDouble, inBacktick, escaped bool
+	for _, r := range command {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\' && !inSingle:
+			escaped = true
+		case r == '\'' && !inDouble && !inBacktick:
+			inSingle = !inSingle
+		case r == '"' && !inSingle:
+			inDouble = !inDouble
+		case r == '`' && !inSingle:
+			inBacktick = !inBacktick
+		}
+	}
+
+	switch {
+	case inSingle:
+		warnings = append(warnings, fmt.Sprintf(ui.Msg.WarningCommandUnbalanced, "'"))
+	case inDouble:
+		warnings = append(warnings, fmt.Sprintf(ui.Msg.WarningCommandUnbalanced, "\""))
+	case inBacktick:
+		warnings = append(warnings, fmt.Sprintf(ui.Msg.WarningCommandUnbalanced, "`"))
+	}
+	if escaped {
+		warnings = append(warnings, ui.Msg.WarningCommandTrailingBackslash)
+	}
+	if found := DetectSecrets(command); len(found) > 0 {
+		warnings = append(warnings, fmt.Sprintf(ui.Msg.WarningCommandSecret, strings.Join(found, ", ")))
+	}
+	return warnings
+}
+
+// ValidateStoredName checks a name read from a store or file, for which the shell is not
+// known: it must be valid in at least one supported shell, so it never carries shell syntax.
+func ValidateStoredName(name string) error {
+	var first error
+	for _, shell := range []string{"bash", "zsh", "fish"} {
+		err := ValidateName(name, shell)
+		if err == nil {
+			return nil
+		}
+		if first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+// ValidateAliases checks the names of a set of aliases, e.g. before an import.
+// User-level aliases are validated for shellType, global ones for every shell.
+func ValidateAliases(aliases []Alias, shellType string) []error {
+	var errs []error
+	for _, a := range aliases {
+		target := shellType
+		if a.Level == "global" {
+			target = ""
+		}
+		if err := ValidateName(a.Name, target); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// PrintCommandWarnings prints the warnings returned by CheckCommand for an alias.
+func PrintCommandWarnings(name string, warnings []string) {
+	for _, w := range warnings {
+		fmt.Printf("%s⚠️  %s%s\n", ui.Color.Yellow, fmt.Sprintf(ui.Msg.WarningCommandPrefix, name, w), ui.Color.Reset)
+	}
+}
@@ -0,0 +1,106 @@
+package alias
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"quickalias/internal/ui"
+)
+
+func TestValidateName(t *testing.T) {
+	tests := []struct {
+		name, shell string
+		valid       bool
+	}{
+		{"ll", "bash", true},
+		{"git-st", "zsh", true},
+		{"g.s", "fish", true},
+		{"ünï", "bash", true},
+		{"", "bash", false},
+		{"ls -la", "bash", false},
+		{"a\tb", "bash", false},
+		{"foo=bar", "bash", false},
+		{"x;rm", "zsh", false},
+		{"$(id)", "bash", false},
+		{"`id`", "bash", false},
+		{"a'b", "bash", false},
+		{`a"b`, "bash", false},
+		{"a/b", "bash", false},
+		{"a|b", "bash", false},
+		{"a>b", "bash", false},
+		{"-x", "bash", false},
+		{"if", "bash", false},
+		{"function", "zsh", false},
+		{"then", "zsh", false},
+		{"foreach", "zsh", false},
+		{"foreach", "bash", true},
+		{"end", "fish", false},
+		{"end", "bash", true},
+		{"g*", "fish", false},
+		{"g*", "bash", true},
+		{"~x", "fish", false},
+		{"g*", "", false}, // Global aliases must work in every shell.
+		{"end", "", false},
+	}
+	for _, tt := range tests {
+		if err := ValidateName(tt.name, tt.shell); (err == nil) != tt.valid {
+			t.Errorf("ValidateName(%q, %q) = %v, want valid %v", tt.name, tt.shell, err, tt.valid)
+		}
+	}
+}
+
+func TestValidateStoredName(t *testing.T) {
+	tests := []struct {
+		name  string
+		valid bool
+	}{
+		{"ll", true},
+		{"end", true}, // Reserved in zsh and fish only.
+		{"g*", true},  // Invalid in fish only.
+		{"if", false}, // Reserved everywhere.
+		{"x;id", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if err := ValidateStoredName(tt.name); (err == nil) != tt.valid {
+			t.Errorf("ValidateStoredName(%q) = %v, want valid %v", tt.name, err, tt.valid)
+		}
+	}
+}
+
+func TestValidateAliases(t *testing.T) {
+	aliases := []Alias{
+		{Name: "g*", Level: "user"},
+		{Name: "g*", Level: "global"},
+		{Name: "ok", Level: "global"},
+		{Name: "a b", Level: "user"},
+	}
+	if errs := ValidateAliases(aliases, "bash"); len(errs) != 2 {
+		t.Errorf("ValidateAliases = %v, want errors for the global g* and for 'a b'", errs)
+	}
+}
+
+func TestCheckCommand(t *testing.T) {
+	tests := []struct {
+		command string
+		want    []string
+	}{
+		{"ls -la", nil},
+		{`echo "it's"`, nil},
+		{`echo 'say "hi"'`, nil},
+		{`echo \"`, nil},
+		{"echo `date`", nil},
+		{"   ", []string{ui.Msg.WarningCommandEmpty}},
+		{"echo 'oops", []string{fmt.Sprintf(ui.Msg.WarningCommandUnbalanced, "'")}},
+		{`echo "oops`, []string{fmt.Sprintf(ui.Msg.WarningCommandUnbalanced, `"`)}},
+		{"echo `oops", []string{fmt.Sprintf(ui.Msg.WarningCommandUnbalanced, "`")}},
+		{`echo \`, []string{ui.Msg.WarningCommandTrailingBackslash}},
+		{`echo '\'`, nil}, // No escapes inside single quotes.
+	}
+	for _, tt := range tests {
+		if got := CheckCommand(tt.command); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("CheckCommand(%q) = %q, want %q", tt.command, got, tt.want)
+		}
+	}
+}
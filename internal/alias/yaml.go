@@ -0,0 +1,266 @@
+package alias
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// The YAML reader covers what alias files need: block mappings and sequences, plain,
+// quoted and block (| >) scalars, flow lists and maps, and comments. Anchors, tags
+// and multiple documents are not supported.
+
+// yamlLine is a line of a YAML document that holds content.
+type yamlLine struct {
+	number int    // Index in the document's lines.
+	indent int    // Leading spaces.
+	text   string // The line without its indentation.
+}
+
+// yamlParser walks the content lines of a document.
+type yamlParser struct {
+	raw   []string
+	lines []yamlLine
+	pos   int
+}
+
+// parseYAML parses a YAML document into a tree of []field, []interface{} and scalars.
+func parseYAML(data []byte) (interface{}, error) {
+	p := &yamlParser{raw: strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")}
+	for n, line := range p.raw {
+		text := strings.TrimLeft(line, " ")
+		if text == "" || strings.HasPrefix(text, "#") || text == "---" || text == "..." {
+			continue
+		}
+		if strings.HasPrefix(text, "\t") {
+			return nil, fmt.Errorf("line %d: tabs cannot be used for indentation", n+1)
+		}
+		p.lines = append(p.lines, yamlLine{number: n, indent: len(line) - len(text), text: text})
+	}
+	if len(p.lines) == 0 {
+		return nil, nil
+	}
+	value, err := p.node(0)
+	if err == nil && p.pos < len(p.lines) {
+		err = p.errorf("unexpected content")
+	}
+	return value, err
+}
+
+// errorf returns an error about the current line.
+func (p *yamlParser) errorf(format string, a ...interface{}) error {
+	line := len(p.raw)
+	if p.pos < len(p.lines) {
+		line = p.lines[p.pos].number + 1
+	}
+	return fmt.Errorf("line %d: %s", line, fmt.Sprintf(format, a...))
+}
+
+// node parses the mapping, sequence or scalar starting at the current line.
+func (p *yamlParser) node(minIndent int) (interface{}, error) {
+	if p.pos >= len(p.lines) || p.lines[p.pos].indent < minIndent {
+		return nil, nil
+	}
+	l := p.lines[p.pos]
+	if isYAMLSequenceItem(l.text) {
+		return p.sequence(l.indent)
+	}
+	if _, _, ok := splitYAMLKey(l.text); ok {
+		return p.mapping(l.indent)
+	}
+	p.pos++
+	return p.scalar(l.text)
+}
+
+// sequence parses "- item" lines at indent.
+func (p *yamlParser) sequence(indent int) (interface{}, error) {
+	values := []interface{}{}
+	for p.pos < len(p.lines) && p.lines[p.pos].indent == indent && isYAMLSequenceItem(p.lines[p.pos].text) {
+		l := p.lines[p.pos]
+		rest := strings.TrimLeft(l.text[1:], " ")
+		if rest == "" {
+			p.pos++
+			value, err := p.node(indent + 1)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+			continue
+		}
+
+		// Parse the rest of the line as if it started a block at its own column.
+		column := indent + len(l.text) - len(rest)
+		p.lines[p.pos] = yamlLine{number: l.number, indent: column, text: rest}
+		value, err := p.node(column)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	if p.pos < len(p.lines) && p.lines[p.pos].indent > indent {
+		return nil, p.errorf("unexpected indentation")
+	}
+	return values, nil
+}
+
+// mapping parses "key: value" lines at indent.
+func (p *yamlParser) mapping(indent int) (interface{}, error) {
+	var fields []field
+	for p.pos < len(p.lines) && p.lines[p.pos].indent == indent && !isYAMLSequenceItem(p.lines[p.pos].text) {
+		l := p.lines[p.pos]
+		key, rest, ok := splitYAMLKey(l.text)
+		if !ok {
+			return nil, p.errorf("expected key: value")
+		}
+		p.pos++
+
+		var value interface{}
+		var err error
+		switch {
+		case rest == "" || strings.HasPrefix(rest, "#"):
+			// The value is a nested block, which may be a sequence at the same indentation.
+			if p.pos < len(p.lines) {
+				next := p.lines[p.pos]
+				if next.indent > indent || next.indent == indent && isYAMLSequenceItem(next.text) {
+					value, err = p.node(next.indent)
+				}
+			}
+		case rest[0] == '|' || rest[0] == '>':
+			value, err = p.blockScalar(l, indent, rest)
+		default:
+			value, err = p.scalar(rest)
+		}
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, field{key: key, value: value, line: l.number + 1})
+	}
+	if p.pos < len(p.lines) && p.lines[p.pos].indent > indent {
+		return nil, p.errorf("unexpected indentation")
+	}
+	return fields, nil
+}
+
+// scalar parses a value written on one line, or a quoted or flow value continued on the
+// following lines, whose line breaks become spaces.
+func (p *yamlParser) scalar(text string) (interface{}, error) {
+	line := p.lines[p.pos-1].number + 1
+	if !strings.ContainsRune(`"'[{`, rune(text[0])) {
+		if i := strings.Index(text, " #"); i >= 0 {
+			text = text[:i]
+		}
+		return bareValue(strings.TrimSpace(text)), nil
+	}
+	for {
+		value, end, err := flowValue(text, 0, true)
+		if errors.Is(err, errUnterminatedValue) && p.pos < len(p.lines) {
+			text += " " + p.lines[p.pos].text
+			p.pos++
+			continue
+		}
+		if err == nil && !trailingComment(text[end:]) {
+			err = fmt.Errorf("unexpected %q after value", strings.TrimSpace(text[end:]))
+		}
+		if err != nil {
+			p.pos--
+			return nil, p.errorf("%v", err)
+		}
+		setLine(value, line)
+		return value, nil
+	}
+}
+
+// blockScalar reads a literal (|) or folded (>) block scalar for the key on line l.
+func (p *yamlParser) blockScalar(l yamlLine, indent int, header string) (interface{}, error) {
+	if i := strings.Index(header, " #"); i >= 0 {
+		header = header[:i]
+	}
+	header = strings.TrimSpace(header)
+	folded, chomp := header[0] == '>', strings.TrimLeft(header[1:], "123456789")
+
+	var lines []string
+	blockIndent, last := -1, l.number
+	for n := l.number + 1; n < len(p.raw); n++ {
+		line := p.raw[n]
+		text := strings.TrimLeft(line, " ")
+		if text == "" {
+			lines = append(lines, "")
+			continue
+		}
+		if blockIndent < 0 {
+			blockIndent = len(line) - len(text)
+		}
+		if len(line)-len(text) < blockIndent || blockIndent <= indent {
+			break
+		}
+		lines, last = append(lines, line[blockIndent:]), n
+	}
+	if chomp != "+" {
+		lines = lines[:len(lines)-countTrailingBlank(lines)]
+	}
+	for p.pos < len(p.lines) && p.lines[p.pos].number <= last {
+		p.pos++
+	}
+
+	var sb strings.Builder
+	for i, line := range lines {
+		switch {
+		case i == 0:
+		case folded && foldable(lines[i-1]) && foldable(line):
+			sb.WriteString(" ")
+		case folded && foldable(lines[i-1]) && line == "":
+			// The break before a run of empty lines is folded away; each empty line is one.
+		default:
+			sb.WriteString("\n")
+		}
+		sb.WriteString(line)
+	}
+	text := sb.String()
+	if chomp != "-" && len(lines) > 0 {
+		text += "\n"
+	}
+	return text, nil
+}
+
+// foldable reports whether a line of a folded block scalar is joined to its neighbours:
+// empty and more indented lines keep their line breaks.
+func foldable(line string) bool {
+	return line != "" && line[0] != ' '
+}
+
+// countTrailingBlank returns the number of empty lines at the end of lines.
+func countTrailingBlank(lines []string) int {
+	n := 0
+	for n < len(lines) && lines[len(lines)-1-n] == "" {
+		n++
+	}
+	return n
+}
+
+// isYAMLSequenceItem reports whether text starts a "- " sequence item.
+func isYAMLSequenceItem(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+// splitYAMLKey splits "key: value" into its key and the rest of the line.
+func splitYAMLKey(text string) (string, string, bool) {
+	if text[0] == '{' || text[0] == '[' {
+		return "", "", false // A flow mapping or list, whose keys are its own.
+	}
+	if text[0] == '"' || text[0] == '\'' {
+		key, end, err := flowValue(text, 0, true)
+		if s, ok := key.(string); err == nil && ok && strings.HasPrefix(text[end:], ":") {
+			return s, strings.TrimSpace(text[end+1:]), true
+		}
+		return "", "", false
+	}
+	for i := 0; i < len(text); i++ {
+		if text[i] == ':' && (i+1 == len(text) || text[i+1] == ' ' || text[i+1] == '\t') {
+			return strings.TrimSpace(text[:i]), strings.TrimSpace(text[i+1:]), i > 0
+		}
+		if text[i] == ' ' && i+1 < len(text) && text[i+1] == '#' {
+			break
+		}
+	}
+	return "", "", false
+}
@@ -0,0 +1,87 @@
+package alias
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDecodeYAML(t *testing.T) {
+	tests := []struct {
+		name, data string
+		want       []Alias
+	}{
+		{"list", "- name: ll\n  command: ls -la\n- name: gs\n  command: git status\n",
+			[]Alias{{Name: "ll", Command: "ls -la"}, {Name: "gs", Command: "git status"}}},
+		{"aliases key", "# mine\naliases:\n  - name: ll\n    command: ls -la\n",
+			[]Alias{{Name: "ll", Command: "ls -la"}}},
+		{"aliases key, items not indented", "aliases:\n- name: ll\n  command: ls -la\n",
+			[]Alias{{Name: "ll", Command: "ls -la"}}},
+		{"nested item", "-\n  name: ll\n  command: ls\n",
+			[]Alias{{Name: "ll", Command: "ls"}}},
+		{"comments and document markers", "---\n# aliases\n- name: ll # list\n  command: ls -l # long\n...\n",
+			[]Alias{{Name: "ll", Command: "ls -l"}}},
+		{"double quotes", `- {name: gl, command: "git log --format=\"%h %s\"\n"}` + "\n",
+			[]Alias{{Name: "gl", Command: "git log --format=\"%h %s\"\n"}}},
+		{"single quotes", "- name: x\n  command: 'echo ''hi'' # not a comment'\n",
+			[]Alias{{Name: "x", Command: "echo 'hi' # not a comment"}}},
+		{"quoted value on two lines", "- name: x\n  command: \"echo a\n    b\"\n",
+			[]Alias{{Name: "x", Command: "echo a b"}}},
+		{"quoted key", "- \"name\": ll\n  'command': ls\n",
+			[]Alias{{Name: "ll", Command: "ls"}}},
+		{"flow list", "- name: k\n  command: kubectl\n  tags: [k8s, \"ops\"]\n",
+			[]Alias{{Name: "k", Command: "kubectl", Tags: []string{"k8s", "ops"}}}},
+		{"flow list on two lines", "- name: k\n  command: kubectl\n  tags: [k8s,\n    ops]\n",
+			[]Alias{{Name: "k", Command: "kubectl", Tags: []string{"k8s", "ops"}}}},
+		{"nested mapping", "- name: k\n  command: kubectl\n  conditions:\n    hosts: [\"web-*\"]\n    requires:\n      - kubectl\n",
+			[]Alias{{Name: "k", Command: "kubectl", Conditions: &Conditions{Hosts: []string{"web-*"}, Requires: []string{"kubectl"}}}}},
+		{"booleans", "- name: x\n  command: ls\n  disabled: true\n  allow_shadow: false\n",
+			[]Alias{{Name: "x", Command: "ls", Disabled: true}}},
+		{"literal block", "- name: x\n  command: |\n    echo a\n      echo b\n\n  level: user\n",
+			[]Alias{{Name: "x", Command: "echo a\n  echo b\n", Level: "user"}}},
+		{"literal block, strip", "- name: x\n  command: |-\n    echo a\n    echo b\n",
+			[]Alias{{Name: "x", Command: "echo a\necho b"}}},
+		{"literal block, keep", "- name: x\n  command: |+\n    echo a\n\n- name: y\n  command: ls\n",
+			[]Alias{{Name: "x", Command: "echo a\n\n"}, {Name: "y", Command: "ls"}}},
+		{"folded block", "- name: x\n  command: >\n    echo a\n    b\n\n    c\n",
+			[]Alias{{Name: "x", Command: "echo a b\nc\n"}}},
+		{"folded block, more indented line", "- name: x\n  command: >-\n    echo a\n      b\n    c\n",
+			[]Alias{{Name: "x", Command: "echo a\n  b\nc"}}},
+		{"empty document", "# nothing\n", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeAliases("aliases.yaml", []byte(tt.data))
+			if err != nil {
+				t.Fatalf("DecodeAliases: %v", err)
+			}
+			if len(got) == 0 && len(tt.want) == 0 {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got  %+v\nwant %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeYAMLErrors(t *testing.T) {
+	tests := []struct {
+		name, data, err string
+	}{
+		{"tab indentation", "- name: ll\n\tcommand: ls\n", "line 2: tabs cannot be used for indentation"},
+		{"unterminated quote", "- name: ll\n  command: \"ls\n", "line 2: unterminated value"},
+		{"text after quoted value", "- name: ll\n  command: \"ls\" -l\n", `line 2: unexpected "-l" after value`},
+		{"bad indentation", "- name: ll\n    command: ls\n", "line 2: unexpected indentation"},
+		{"not a mapping", "- name: ll\n  just text\n", "line 2: "},
+		{"unterminated flow list", "- name: k\n  command: k\n  tags: [a, b\n", "line 3: unterminated value"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := DecodeAliases("aliases.yaml", []byte(tt.data))
+			if err == nil || !strings.HasPrefix(err.Error(), tt.err) {
+				t.Errorf("err = %v, want %q", err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package cli
+
+// Completion kinds describe what a positional argument expects.
+// They are resolved to concrete values by the provider passed to Complete.
+const (
+	CompleteNone          = ""
+	CompleteAliases       = "aliases"        // Alias names from every level.
+	CompleteUserAliases   = "user-aliases"   // Alias names from the user level.
+	CompleteGlobalAliases = "global-aliases" // Alias names from the global level.
+	CompleteBackups       = "backups"        // Backup file names.
+	CompleteFiles         = "files"          // Paths on disk, completed by the shell itself.
+	CompleteShells        = "shells"         // Shells supported by `qq completion`.
+	CompleteTags          = "tags"           // Tags used by existing aliases.
+	CompleteCategories    = "categories"     // Categories used by existing aliases.
+	CompletePacks         = "packs"          // Names of the installed packs.
+)
+
+// Command describes a single qq command. The command table built from these
+// structs is the single source for dispatching, help and shell completion.
+type Command struct {
+	Name        string
+	Aliases     []string // Alternative names, e.g. "control" for "status".
+	Group       string   // Section heading under which the command is listed in the usage overview.
+	Usage       string   // Argument synopsis shown after the command name.
+	Summary     string   // One-line description.
+	Description string   // Optional longer text shown by `qq help <command>`.
+	Hidden      bool     // Internal commands are not listed in help or completion.
+	Args        []string // Completion kind for each positional argument.
+	MinArgs     int      // Minimum number of positional arguments.
+	MaxArgs     int      // Maximum number of positional arguments; -1 means unlimited.
+	Flags       []*Flag
+
+	StopAtFirstArg bool   // Flags are only recognized before the first positional argument; the rest is passed verbatim.
+	RawArgs        bool   // Arguments are passed through without any flag parsing.
+	SkipInit       bool   // The command works before `qq setup` has been run.
+	Privileged     bool   // The command needs root and is retried through sudo.
+	PrivilegedFlag string // Boolean flag that makes the command privileged, e.g. "global".
+
+	Run         func(ctx *Context) error
+	Subcommands []*Command
+}
+
+// Matches reports whether name refers to this command.
+func (c *Command) Matches(name string) bool {
+	if c.Name == name {
+		return true
+	}
+	for _, a := range c.Aliases {
+		if a == name {
+			return true
+		}
+	}
+	return false
+}
+
+// OwnsFlag reports whether the command defines the named flag itself, which shadows
+// a global flag of the same name.
+func (c *Command) OwnsFlag(name string) bool {
+	return findFlag(name, false, c.Flags) != nil
+}
+
+// NeedsPrivileges reports whether this invocation of the command needs root.
+func (c *Command) NeedsPrivileges(ctx *Context) bool {
+	return c.Privileged || (c.PrivilegedFlag != "" && ctx.Bool(c.PrivilegedFlag))
+}
+
+// Find returns the command in commands that matches name, or nil.
+func Find(commands []*Command, name string) *Command {
+	for _, c := range commands {
+		if c.Matches(name) {
+			return c
+		}
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package cli
+
+import (
+	"sort"
+	"strings"
+)
+
+// FilesDirective is printed by `qq __complete` when the shell should fall back to path completion.
+const FilesDirective = ":files"
+
+// KindPrefix starts a `qq __complete` word that asks for the values of a completion kind
+// directly, such as ":backups", for values no command argument takes.
+const KindPrefix = ":"
+
+// Candidate is a single completion suggestion.
+type Candidate struct {
+	Value       string
+	Description string
+}
+
+// Complete returns the completion candidates for the last element of words,
+// which is the (possibly empty) word under the cursor. provider resolves completion
+// kinds such as CompleteUserAliases to values. The boolean result is true when
+// the shell should complete file paths instead. A single word starting with KindPrefix
+// returns every value of that kind.
+func Complete(commands []*Command, words []string, provider func(kind string) []string) ([]Candidate, bool) {
+	if len(words) == 0 {
+		words = []string{""}
+	}
+	if len(words) == 1 && len(words[0]) > len(KindPrefix) && strings.HasPrefix(words[0], KindPrefix) {
+		return valueCandidates(provider(strings.TrimPrefix(words[0], KindPrefix))), false
+	}
+	current := words[len(words)-1]
+	before := words[:len(words)-1]
+	globals := GlobalFlags()
+
+	// Walk the words before the cursor the same way Parse does.
+	level := commands
+	var cmd *Command
+	var positionals []string
+	var pending *Flag // Flag still waiting for its value.
+	flagsDone := false
+	for _, word := range before {
+		if pending != nil {
+			pending = nil
+			continue
+		}
+		if !flagsDone && word == "--" {
+			flagsDone = true
+			continue
+		}
+		if !flagsDone && len(word) > 1 && strings.HasPrefix(word, "-") {
+			name, _, hasValue, short := splitFlag(word)
+			var cmdFlags []*Flag
+			if cmd != nil {
+				cmdFlags = cmd.Flags
+			}
+			if f := findFlag(name, short, cmdFlags, globals); f != nil && f.TakesValue() && !hasValue {
+				pending = f
+			}
+			continue
+		}
+		if len(positionals) == 0 && level != nil {
+			if next := Find(level, word); next != nil {
+				cmd = next
+				level = next.Subcommands
+				continue
+			}
+		}
+		positionals = append(positionals, word)
+		if cmd != nil && cmd.StopAtFirstArg {
+			flagsDone = true
+		}
+	}
+
+	if pending != nil {
+		values := pending.Values
+		if len(values) == 0 && pending.Complete != "" {
+			values = provider(pending.Complete)
+		}
+		return filterCandidates(valueCandidates(values), current), false
+	}
+
+	if !flagsDone && strings.HasPrefix(current, "-") {
+		var flags []*Flag
+		if cmd != nil {
+			flags = append(flags, cmd.Flags...)
+		}
+		return filterCandidates(flagCandidates(append(flags, globals...)), current), false
+	}
+
+	switch {
+	case cmd == nil && len(positionals) == 0:
+		return filterCandidates(commandCandidates(commands), current), false
+	case cmd == nil:
+		return nil, false
+	case len(cmd.Subcommands) > 0 && len(positionals) == 0:
+		return filterCandidates(commandCandidates(cmd.Subcommands), current), false
+	case len(positionals) >= len(cmd.Args):
+		return nil, false
+	}
+
+	kind := cmd.Args[len(positionals)]
+	if kind == CompleteFiles {
+		return nil, true
+	}
+	return filterCandidates(valueCandidates(provider(kind)), current), false
+}
+
+// flagCandidates converts flags into candidates using their long spelling.
+func flagCandidates(flags []*Flag) []Candidate {
+	var candidates []Candidate
+	for _, f := range flags {
+		candidates = append(candidates, Candidate{Value: "--" + f.Name, Description: f.Usage})
+	}
+	return candidates
+}
+
+// valueCandidates converts plain values into candidates.
+func valueCandidates(values []string) []Candidate {
+	var candidates []Candidate
+	for _, v := range values {
+		candidates = append(candidates, Candidate{Value: v})
+	}
+	return candidates
+}
+
+// commandCandidates converts the visible commands into candidates.
+func commandCandidates(commands []*Command) []Candidate {
+	var candidates []Candidate
+	for _, c := range commands {
+		if c.Hidden {
+			continue
+		}
+		candidates = append(candidates, Candidate{Value: c.Name, Description: c.Summary})
+		for _, a := range c.Aliases {
+			if strings.HasPrefix(a, "-") {
+				continue // Flag spellings such as --help are not offered as commands.
+			}
+			candidates = append(candidates, Candidate{Value: a, Description: c.Summary})
+		}
+	}
+	return candidates
+}
+
+// filterCandidates keeps the candidates starting with prefix, sorted and without duplicates.
+func filterCandidates(candidates []Candidate, prefix string) []Candidate {
+	seen := make(map[string]bool)
+	matches := []Candidate{}
+	for _, c := range candidates {
+		if strings.HasPrefix(c.Value, prefix) && !seen[c.Value] {
+			seen[c.Value] = true
+			matches = append(matches, c)
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool { return matches[i].Value < matches[j].Value })
+	return matches
+}
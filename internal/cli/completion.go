@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"quickalias/internal/ui"
+)
+
+// CompletionShells lists the shells `qq completion` can generate scripts for.
+var CompletionShells = []string{"bash", "zsh", "fish"}
+
+// GenerateCompletion returns the completion script for shell. The scripts are thin
+// wrappers that ask `<program> __complete` for candidates, so the Go command table
+// stays the only place where commands and their arguments are described.
+func GenerateCompletion(shell, program string) (string, error) {
+	var script string
+	switch shell {
+	case "bash":
+		script = bashCompletion
+	case "zsh":
+		script = zshCompletion
+	case "fish":
+		script = fishCompletion
+	default:
+		return "", fmt.Errorf(ui.Msg.ErrorUnsupportedCompletionShell, shell)
+	}
+	return strings.NewReplacer("{{PROG}}", program, "{{FILES}}", FilesDirective).Replace(script), nil
+}
+
+const bashCompletion = `# bash completion for {{PROG}}, generated by "{{PROG}} completion bash"
+_{{PROG}}_completion() {
+    local cur="${COMP_WORDS[COMP_CWORD]}"
+    local out
+    out=$({{PROG}} __complete "${COMP_WORDS[@]:1:COMP_CWORD}" 2>/dev/null)
+    if [[ "$out" == "{{FILES}}" ]]; then
+        COMPREPLY=( $(compgen -f -- "$cur") )
+        return 0
+    fi
+    local IFS=$'\n'
+    COMPREPLY=( $(printf '%s\n' "$out" | cut -f1) )
+}
+
+complete -o filenames -F _{{PROG}}_completion {{PROG}}
+`
+
+const zshCompletion = `#compdef {{PROG}}
+# zsh completion for {{PROG}}, generated by "{{PROG}} completion zsh"
+
+_{{PROG}}() {
+    local out line value desc
+    local -a candidates
+    out=$({{PROG}} __complete "${(@)words[2,CURRENT]}" 2>/dev/null)
+    if [[ "$out" == "{{FILES}}" ]]; then
+        _files
+        return
+    fi
+    for line in ${(f)out}; do
+        value=${line%%$'\t'*}
+        desc=${line#*$'\t'}
+        [[ "$desc" == "$line" ]] && desc=""
+        candidates+=("${value//:/\\:}:${desc}")
+    done
+    _describe '{{PROG}}' candidates
+}
+
+if [[ "${funcstack[1]}" == "_{{PROG}}" ]]; then
+    _{{PROG}} "$@"
+else
+    compdef _{{PROG}} {{PROG}}
+fi
+`
+
+const fishCompletion = `# fish completion for {{PROG}}, generated by "{{PROG}} completion fish"
+function __{{PROG}}_complete
+    set -l tokens (commandline -opc) (commandline -ct)
+    set -e tokens[1]
+    set -l out ({{PROG}} __complete $tokens 2>/dev/null)
+    if test "$out" = "{{FILES}}"
+        __fish_complete_path (commandline -ct)
+        return
+    end
+    printf '%s\n' $out
+end
+
+complete -c {{PROG}} -f -a '(__{{PROG}}_complete)'
+`
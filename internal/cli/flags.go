@@ -0,0 +1,106 @@
+package cli
+
+import "strconv"
+
+// FlagKind is the type of value a flag carries.
+type FlagKind int
+
+const (
+	BoolFlag    FlagKind = iota // --name, --name=false
+	StringFlag                  // --name value, --name=value
+	StringsFlag                 // Repeatable string flag: --name a --name b
+)
+
+// Flag describes a command-line flag.
+type Flag struct {
+	Name     string // Long name without dashes, e.g. "yes".
+	Short    string // Optional one-letter name without the dash, e.g. "y".
+	Kind     FlagKind
+	Value    string   // Placeholder for the value in help output, e.g. "<tag>".
+	Values   []string // Accepted values, offered by shell completion.
+	Complete string   // Completion kind for the value when Values is empty, resolved by the provider.
+	Usage    string
+}
+
+// TakesValue reports whether the flag consumes a value.
+func (f *Flag) TakesValue() bool {
+	return f.Kind != BoolFlag
+}
+
+// matches reports whether the given spelling (without dashes) refers to this flag.
+func (f *Flag) matches(name string, short bool) bool {
+	if short {
+		return f.Short != "" && f.Short == name
+	}
+	return f.Name == name
+}
+
+// Spelling returns the flag as shown in help, e.g. "--yes, -y".
+func (f *Flag) Spelling() string {
+	spelling := "--" + f.Name
+	if f.Short != "" {
+		spelling += ", -" + f.Short
+	}
+	if f.Value != "" {
+		spelling += " " + f.Value
+	}
+	return spelling
+}
+
+// findFlag looks up a flag by its spelling in the given flag sets.
+func findFlag(name string, short bool, sets ...[]*Flag) *Flag {
+	for _, set := range sets {
+		for _, f := range set {
+			if f.matches(name, short) {
+				return f
+			}
+		}
+	}
+	return nil
+}
+
+// Context carries the parsed arguments and flag values of an invocation.
+type Context struct {
+	Args   []string
+	values map[string][]string
+}
+
+// newContext returns an empty Context.
+func newContext() *Context {
+	return &Context{values: make(map[string][]string)}
+}
+
+// set records a value for the flag with the given canonical name.
+func (c *Context) set(name, value string) {
+	c.values[name] = append(c.values[name], value)
+}
+
+// IsSet reports whether the flag was given on the command line.
+func (c *Context) IsSet(name string) bool {
+	_, ok := c.values[name]
+	return ok
+}
+
+// Bool returns the value of a boolean flag.
+func (c *Context) Bool(name string) bool {
+	values := c.values[name]
+	if len(values) == 0 {
+		return false
+	}
+	b, _ := strconv.ParseBool(values[len(values)-1])
+	return b
+}
+
+// String returns the last value given for a string flag.
+func (c *Context) String(name string) string {
+	values := c.values[name]
+	if len(values) == 0 {
+		return ""
+	}
+	return values[len(values)-1]
+}
+
+// Strings returns every value given for a repeatable flag.
+func (c *Context) Strings(name string) []string {
+	return c.values[name]
+}
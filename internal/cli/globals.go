@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"fmt"
+
+	"quickalias/internal/ui"
+)
+
+// GlobalFlags returns the flags that are accepted by every command.
+func GlobalFlags() []*Flag {
+	return []*Flag{
+		{Name: "yes", Short: "y", Kind: BoolFlag, Usage: ui.Msg.FlagYesSummary},
+		{Name: "force", Short: "f", Kind: BoolFlag, Usage: ui.Msg.FlagForceSummary},
+		{Name: "no-input", Kind: BoolFlag, Usage: ui.Msg.FlagNoInputSummary},
+		{Name: "json", Kind: BoolFlag, Usage: ui.Msg.FlagJSONSummary},
+		{Name: "format", Kind: StringFlag, Value: "<tsv|template>", Values: []string{ui.FormatText, ui.FormatJSON, ui.FormatTSV}, Usage: ui.Msg.FlagFormatSummary},
+		{Name: "color", Kind: StringFlag, Value: "<auto|always|never>", Values: []string{ui.ColorAuto, ui.ColorAlways, ui.ColorNever}, Usage: ui.Msg.FlagColorSummary},
+	}
+}
+
+// GlobalOptions holds the values of the flags that are accepted by every command.
+type GlobalOptions struct {
+	Yes     bool   // --yes, -y, --force, -f: answer confirmations with yes.
+	NoInput bool   // --no-input: never prompt, fail instead.
+	JSON    bool   // --json: shorthand for --format json.
+	Format  string // --format: text, json, tsv or a Go template.
+	Color   string // --color: auto, always or never.
+}
+
+// GlobalOptions extracts the global flag values from the parsed context.
+func (c *Context) GlobalOptions() GlobalOptions {
+	return GlobalOptions{
+		Yes:     c.Bool("yes") || c.Bool("force"),
+		NoInput: c.Bool("no-input"),
+		JSON:    c.Bool("json"),
+		Format:  c.String("format"),
+		Color:   c.String("color"),
+	}
+}
+
+// OutputFormat resolves --json and --format into the format used to render command output.
+func (o GlobalOptions) OutputFormat() (ui.OutputFormat, error) {
+	if o.JSON {
+		if o.Format != "" && o.Format != ui.FormatJSON {
+			return ui.OutputFormat{}, fmt.Errorf(ui.Msg.ConflictingFormatFlags)
+		}
+		return ui.ParseOutputFormat(ui.FormatJSON)
+	}
+	return ui.ParseOutputFormat(o.Format)
+}
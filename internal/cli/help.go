@@ -0,0 +1,173 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"quickalias/internal/ui"
+)
+
+// Program is the name used for qq in help output.
+const Program = "qq"
+
+// synopsis returns "qq <path> <usage>" for a command.
+func synopsis(path []string, cmd *Command) string {
+	line := Program + " " + strings.Join(path, " ")
+	if len(cmd.Flags) > 0 {
+		line += " [" + ui.Msg.HelpFlagsPlaceholder + "]"
+	}
+	if cmd.Usage != "" {
+		line += " " + cmd.Usage
+	}
+	return line
+}
+
+// printEntry prints an indented two-column line, padding the left column to width.
+func printEntry(indent, left string, width int, right string) {
+	fmt.Printf("%s%s%-*s%s  %s\n", indent, ui.Color.White, width, left, ui.Color.Reset, right)
+}
+
+// PrintUsage prints the overview of all visible commands, grouped by section.
+func PrintUsage(commands []*Command) {
+	fmt.Printf("%s%s%s\n", ui.Color.Cyan+ui.Color.Bold, ui.Msg.UsageTitle, ui.Color.Reset)
+	fmt.Println()
+	fmt.Printf("%s%s%s\n", ui.Color.Green+ui.Color.Bold, ui.Msg.UsageHeader, ui.Color.Reset)
+
+	// Collect groups in table order.
+	var groups []string
+	byGroup := make(map[string][]*Command)
+	width := 0
+	for _, c := range commands {
+		if c.Hidden {
+			continue
+		}
+		if _, ok := byGroup[c.Group]; !ok {
+			groups = append(groups, c.Group)
+		}
+		byGroup[c.Group] = append(byGroup[c.Group], c)
+		entries := []string{synopsis([]string{c.Name}, c)}
+		for _, sub := range c.Subcommands {
+			entries = append(entries, synopsis([]string{c.Name, sub.Name}, sub))
+		}
+		for _, e := range entries {
+			if len(e) > width {
+				width = len(e)
+			}
+		}
+	}
+
+	for _, group := range groups {
+		fmt.Printf("  %s%s%s\n", ui.Color.Blue, group, ui.Color.Reset)
+		for _, c := range byGroup[group] {
+			if len(c.Subcommands) > 0 {
+				for _, sub := range c.Subcommands {
+					if !sub.Hidden {
+						printEntry("    ", synopsis([]string{c.Name, sub.Name}, sub), width, sub.Summary)
+					}
+				}
+				continue
+			}
+			printEntry("    ", synopsis([]string{c.Name}, c), width, c.Summary)
+		}
+		fmt.Println()
+	}
+
+	printFlags(ui.Msg.UsageGlobalOptions, GlobalFlags())
+
+	fmt.Printf("%s%s%s\n", ui.Color.Cyan, ui.Msg.TipsHeader, ui.Color.Reset)
+	fmt.Printf("  • %s%s%s\n", ui.Color.Yellow, ui.Msg.TipRunSetupFirst, ui.Color.Reset)
+	fmt.Printf("  • %s%s%s\n", ui.Color.Yellow, ui.Msg.TipUserOverridesGlobal, ui.Color.Reset)
+	fmt.Printf("  • %s%s%s\n", ui.Color.Yellow, ui.Msg.TipUseSudoGlobal, ui.Color.Reset)
+	fmt.Printf("  • %s%s%s\n", ui.Color.Yellow, ui.Msg.TipCommandHelp, ui.Color.Reset)
+}
+
+// PrintHelp prints the detailed help for the command at path, or the overview if path is empty.
+func PrintHelp(commands []*Command, path []string) error {
+	if len(path) == 0 {
+		PrintUsage(commands)
+		return nil
+	}
+
+	var cmd *Command
+	level := commands
+	var canonical []string
+	for _, name := range path {
+		cmd = Find(level, name)
+		if cmd == nil {
+			return fmt.Errorf("%s: %s", ui.Msg.UnknownCommand, strings.Join(path, " "))
+		}
+		canonical = append(canonical, cmd.Name)
+		level = cmd.Subcommands
+	}
+
+	fmt.Printf("%s%s%s %s\n", ui.Color.Green+ui.Color.Bold, ui.Msg.UsageHeader, ui.Color.Reset, synopsis(canonical, cmd))
+	fmt.Println()
+	if cmd.Summary != "" {
+		fmt.Println(cmd.Summary)
+	}
+	if cmd.Description != "" {
+		fmt.Println()
+		fmt.Println(cmd.Description)
+	}
+	fmt.Println()
+
+	if len(cmd.Aliases) > 0 {
+		var names []string
+		for _, a := range cmd.Aliases {
+			if !strings.HasPrefix(a, "-") {
+				names = append(names, a)
+			}
+		}
+		if len(names) > 0 {
+			fmt.Printf("  %s%s%s %s\n\n", ui.Color.Blue, ui.Msg.HelpAliases, ui.Color.Reset, strings.Join(names, ", "))
+		}
+	}
+
+	if len(cmd.Subcommands) > 0 {
+		fmt.Printf("  %s%s%s\n", ui.Color.Blue, ui.Msg.HelpSubcommands, ui.Color.Reset)
+		width := 0
+		for _, sub := range cmd.Subcommands {
+			if s := synopsis(append(canonical, sub.Name), sub); len(s) > width {
+				width = len(s)
+			}
+		}
+		for _, sub := range cmd.Subcommands {
+			if !sub.Hidden {
+				printEntry("    ", synopsis(append(canonical, sub.Name), sub), width, sub.Summary)
+			}
+		}
+		fmt.Println()
+	}
+
+	if len(cmd.Flags) > 0 {
+		printFlags(ui.Msg.HelpFlags, cmd.Flags)
+	}
+	printFlags(ui.Msg.UsageGlobalOptions, GlobalFlags())
+	return nil
+}
+
+// printFlags prints a titled list of flags.
+func printFlags(title string, flags []*Flag) {
+	fmt.Printf("  %s%s%s\n", ui.Color.Blue, title, ui.Color.Reset)
+	width := 0
+	for _, f := range flags {
+		if len(f.Spelling()) > width {
+			width = len(f.Spelling())
+		}
+	}
+	for _, f := range flags {
+		printEntry("    ", f.Spelling(), width, f.Usage)
+	}
+	fmt.Println()
+}
+
+// PrintUsageError prints the synopsis of the command an invalid command line was meant for.
+func PrintUsageError(err *UsageError) {
+	if err.Command == nil {
+		fmt.Fprintf(os.Stderr, "%s💡 %s%s\n", ui.Color.Cyan, ui.Msg.HelpOverviewHint, ui.Color.Reset)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "%s%s%s %s\n", ui.Color.Yellow, ui.Msg.UsageHeader, ui.Color.Reset, synopsis(err.Path, err.Command))
+	fmt.Fprintf(os.Stderr, "%s💡 %s%s\n", ui.Color.Cyan, fmt.Sprintf(ui.Msg.HelpHint, strings.Join(err.Path, " ")), ui.Color.Reset)
+}
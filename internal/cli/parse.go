@@ -0,0 +1,138 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"quickalias/internal/ui"
+)
+
+// Invocation is the result of parsing a command line against the command table.
+type Invocation struct {
+	Command *Command // nil when no command was given.
+	Path    []string // Canonical command path, e.g. ["config", "export"].
+	Context *Context
+	Help    bool // --help or -h was given.
+}
+
+// UsageError reports a command line that does not match the command's synopsis.
+type UsageError struct {
+	Path    []string
+	Command *Command
+	Err     error
+}
+
+func (e *UsageError) Error() string { return e.Err.Error() }
+func (e *UsageError) Unwrap() error { return e.Err }
+
+// Parse resolves args against commands, separating positional arguments from
+// command and global flags. Flags may appear anywhere unless the command sets
+// StopAtFirstArg or RawArgs; "--" ends flag parsing.
+func Parse(commands []*Command, args []string) (*Invocation, error) {
+	inv := &Invocation{Context: newContext()}
+	ctx := inv.Context
+	globals := GlobalFlags()
+	level := commands
+	flagsDone := false
+
+	usageErr := func(format string, a ...interface{}) error {
+		return &UsageError{Path: inv.Path, Command: inv.Command, Err: fmt.Errorf(format, a...)}
+	}
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		cmd := inv.Command
+
+		if cmd != nil && cmd.RawArgs {
+			ctx.Args = append(ctx.Args, args[i:]...)
+			break
+		}
+
+		if !flagsDone && arg == "--" {
+			flagsDone = true
+			continue
+		}
+
+		if !flagsDone && len(arg) > 1 && strings.HasPrefix(arg, "-") {
+			if arg == "--help" || arg == "-h" {
+				inv.Help = true
+				continue
+			}
+			name, value, hasValue, short := splitFlag(arg)
+			var cmdFlags []*Flag
+			if cmd != nil {
+				cmdFlags = cmd.Flags
+			}
+			flag := findFlag(name, short, cmdFlags, globals)
+			if flag == nil {
+				return inv, usageErr(ui.Msg.UnknownFlag, arg)
+			}
+			switch {
+			case !flag.TakesValue() && hasValue:
+				ctx.set(flag.Name, value)
+			case !flag.TakesValue():
+				ctx.set(flag.Name, "true")
+			case hasValue:
+				ctx.set(flag.Name, value)
+			case i+1 < len(args):
+				i++
+				ctx.set(flag.Name, args[i])
+			default:
+				return inv, usageErr(ui.Msg.FlagNeedsValue, arg)
+			}
+			continue
+		}
+
+		// Positional word: resolve commands and subcommands first.
+		if len(ctx.Args) == 0 && level != nil {
+			next := Find(level, arg)
+			if next == nil && cmd == nil {
+				return inv, &UsageError{Err: fmt.Errorf("%s: %s", ui.Msg.UnknownCommand, arg)}
+			}
+			if next == nil && cmd.Run == nil {
+				return inv, usageErr(ui.Msg.UnknownSubcommand, arg)
+			}
+			if next != nil {
+				inv.Command = next
+				inv.Path = append(inv.Path, next.Name)
+				level = next.Subcommands
+				continue
+			}
+		}
+
+		ctx.Args = append(ctx.Args, arg)
+		if cmd != nil && cmd.StopAtFirstArg {
+			flagsDone = true
+		}
+	}
+
+	if inv.Help || inv.Command == nil || inv.Command.RawArgs {
+		return inv, nil
+	}
+
+	cmd := inv.Command
+	if cmd.Run == nil && len(cmd.Subcommands) > 0 {
+		return inv, usageErr(ui.Msg.SubcommandRequired)
+	}
+	if len(ctx.Args) < cmd.MinArgs {
+		return inv, usageErr(ui.Msg.NotEnoughArguments)
+	}
+	if cmd.MaxArgs >= 0 && len(ctx.Args) > cmd.MaxArgs {
+		return inv, usageErr(ui.Msg.TooManyArguments, strings.Join(ctx.Args[cmd.MaxArgs:], " "))
+	}
+	return inv, nil
+}
+
+// splitFlag splits "--name=value" or "-n" into its parts.
+func splitFlag(arg string) (name, value string, hasValue, short bool) {
+	if strings.HasPrefix(arg, "--") {
+		name = arg[2:]
+	} else {
+		name = arg[1:]
+		short = true
+	}
+	if i := strings.Index(name, "="); i >= 0 {
+		return name[:i], name[i+1:], true, short
+	}
+	return name, "", false, short
+}
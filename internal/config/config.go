@@ -2,11 +2,11 @@ package config
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
-	"strings"
 
 	"quickalias/internal/alias" // Alias paketinden persist fonksiyonlarına erişim için
 	"quickalias/internal/ui"    // UI paketinden mesajlar ve renkler için
@@ -38,7 +38,12 @@ func SaveConfig(configPath string, cfg *Config, errMsg string) error {
 		return fmt.Errorf(errMsg, err)
 	}
 
-	if err := os.WriteFile(fullConfigPath, data, 0644); err != nil {
+	// config.json is part of the user layer and gets the same mode as the alias store.
+	mode := 0666 &^ alias.UserUmask(cfg.Settings[alias.SettingUmask])
+	if err := os.WriteFile(fullConfigPath, data, mode); err != nil {
+		return fmt.Errorf(errMsg, err)
+	}
+	if err := os.Chmod(fullConfigPath, mode); err != nil {
 		return fmt.Errorf(errMsg, err)
 	}
 
@@ -63,11 +68,15 @@ func tryRemoveFile(filePath, promptMsg string) error {
 		}
 		if os.IsPermission(err) {
 			// Permission denied, automatically try with sudo without asking
-			fmt.Printf("%s%s%s\n", ui.ColorYellow, promptMsg, ui.ColorReset)
-			fmt.Printf("%s sudo rm \"%s\"%s\n", ui.ColorYellow, filePath, ui.ColorReset)
+			fmt.Printf("%s%s%s\n", ui.Color.Yellow, promptMsg, ui.Color.Reset)
+			fmt.Printf("%s sudo rm \"%s\"%s\n", ui.Color.Yellow, filePath, ui.Color.Reset)
 
-			// Directly try with sudo without user confirmation
-			cmd := exec.Command("sudo", "-k", "rm", filePath)
+			// Directly try with sudo without user confirmation; never wait for a password without input.
+			sudoArgs := []string{"-k", "rm", filePath}
+			if !ui.CanPrompt() {
+				sudoArgs = append([]string{"-n"}, sudoArgs...)
+			}
+			cmd := exec.Command("sudo", sudoArgs...)
 			cmd.Stdin = os.Stdin
 			cmd.Stdout = os.Stdout
 			cmd.Stderr = os.Stderr
@@ -85,12 +94,11 @@ func tryRemoveFile(filePath, promptMsg string) error {
 // ResetConfig resets the application's configuration to its default state.
 // It prompts for user confirmation before proceeding.
 func ResetConfig(cfg *Config, version string) error {
-	fmt.Printf("%s%s%s", ui.ColorYellow, ui.Msg.ResetConfigConfirmation, ui.ColorReset)
-	var response string
-	fmt.Scanln(&response)
-	if strings.ToLower(response) != "e" && strings.ToLower(response) != "evet" && strings.ToLower(response) != "y" {
-		fmt.Printf("%s❌ %s%s\n", ui.ColorRed, ui.Msg.ConfigResetCancelled, ui.ColorReset)
-		return nil
+	if err := ui.Confirm(fmt.Sprintf("%s%s%s", ui.Color.Yellow, ui.Msg.ResetConfigConfirmation, ui.Color.Reset)); err != nil {
+		if errors.Is(err, ui.ErrCancelled) {
+			fmt.Printf("%s❌ %s%s\n", ui.Color.Red, ui.Msg.ConfigResetCancelled, ui.Color.Reset)
+		}
+		return err
 	}
 
 	// Get common config directory paths.
@@ -101,7 +109,7 @@ func ResetConfig(cfg *Config, version string) error {
 	appConfigDir := filepath.Join(userConfigDir, appName) // ~/.config/quickalias
 
 	// User alias file path
-	userAliasesFilePath := filepath.Join(appConfigDir, ALIASES_FILE)
+	userAliasesFilePath := alias.StorePath(appConfigDir) // aliases.json, or its YAML/TOML form.
 	if err := tryRemoveFile(userAliasesFilePath, ui.Msg.UserAliasFileRemovePrompt); err != nil {
 		return fmt.Errorf(ui.Msg.ErrorUserAliasFileReset, err)
 	}
@@ -112,7 +120,7 @@ func ResetConfig(cfg *Config, version string) error {
 	if err != nil {
 		return fmt.Errorf(ui.Msg.ErrorGlobalConfigDirNotFound, err)
 	}
-	globalAliasesFilePath := filepath.Join(globalConfigDirPath, GLOBAL_ALIASES_FILE)
+	globalAliasesFilePath := alias.StorePath(globalConfigDirPath)
 
 	if err := tryRemoveFile(globalAliasesFilePath, ui.Msg.GlobalAliasFileRemovePrompt); err != nil {
 		return fmt.Errorf(ui.Msg.ErrorGlobalAliasFileReset, err)
@@ -132,6 +140,6 @@ func ResetConfig(cfg *Config, version string) error {
 		Settings:    make(map[string]string),
 	}
 
-	fmt.Printf("%s✅ %s%s\n", ui.ColorGreen, ui.Msg.ConfigResetSuccess, ui.ColorReset)
+	fmt.Printf("%s✅ %s%s\n", ui.Color.Green, ui.Msg.ConfigResetSuccess, ui.Color.Reset)
 	return nil
 }
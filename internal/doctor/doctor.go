@@ -0,0 +1,315 @@
+package doctor
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"quickalias/internal/alias"
+	"quickalias/internal/shell"
+	"quickalias/internal/ui"
+)
+
+// Check results, from best to worst.
+const (
+	StatusOK   = "ok"
+	StatusSkip = "skip"
+	StatusWarn = "warn"
+	StatusFail = "fail"
+)
+
+// Check is the result of a single health check.
+type Check struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	Message string `json:"message"`
+	Fix     string `json:"fix,omitempty"` // Actionable advice when the check did not pass.
+}
+
+// Report collects the results of all checks.
+type Report struct {
+	Checks   []Check `json:"checks"`
+	Problems int     `json:"problems"` // Number of checks that warned or failed.
+}
+
+// TSVRows implements ui.TSVRecord: one row per check with name, status, message and fix.
+func (r Report) TSVRows() [][]string {
+	rows := make([][]string, 0, len(r.Checks))
+	for _, c := range r.Checks {
+		rows = append(rows, []string{c.Name, c.Status, c.Message, c.Fix})
+	}
+	return rows
+}
+
+// Environment describes the installation that is being checked.
+type Environment struct {
+	ShellType        string
+	Initialized      bool
+	UserConfigPath   string
+	GlobalConfigPath string
+	UserAliases      []alias.Alias
+	GlobalAliases    []alias.Alias
+	InitScript       string // What `qq init` prints, checked as a whole.
+	Umask            string // The umask setting for the user layer; empty for the default.
+}
+
+// Run performs every health check against env.
+func Run(env Environment) Report {
+	var report Report
+	add := func(c Check) {
+		report.Checks = append(report.Checks, c)
+		if c.Status == StatusWarn || c.Status == StatusFail {
+			report.Problems++
+		}
+	}
+
+	add(checkShell(env))
+	add(checkIntegration(env))
+	add(checkInitialized(env))
+	add(checkPath())
+	add(checkStore("user-store", alias.StorePath(env.UserConfigPath), ""))
+	add(checkPermissions(env))
+	add(checkStore("global-store", alias.StorePath(env.GlobalConfigPath), fmt.Sprintf("sudo chmod 644 %s", alias.StorePath(env.GlobalConfigPath))))
+	add(checkGlobalIntegrity(env))
+	add(checkInitSyntax(env))
+	add(checkShadowing(env))
+	return report
+}
+
+// checkShell verifies that a supported shell is configured and installed.
+func checkShell(env Environment) Check {
+	c := Check{Name: "shell"}
+	if _, _, err := shell.ConfigFile(env.ShellType); err != nil {
+		c.Status, c.Message, c.Fix = StatusFail, ui.Msg.DoctorShellNotConfigured, ui.Msg.DoctorFixRunSetup
+		return c
+	}
+	if _, err := exec.LookPath(env.ShellType); err != nil {
+		c.Status, c.Message = StatusWarn, fmt.Sprintf(ui.Msg.DoctorShellMissing, env.ShellType)
+		return c
+	}
+	c.Status, c.Message = StatusOK, fmt.Sprintf(ui.Msg.DoctorShellOK, env.ShellType)
+	return c
+}
+
+// checkIntegration verifies that the shell's rc file loads `qq init`.
+func checkIntegration(env Environment) Check {
+	c := Check{Name: "shell-integration"}
+	configFile, line, err := shell.ConfigFile(env.ShellType)
+	if err != nil {
+		c.Status, c.Message = StatusSkip, ui.Msg.DoctorShellNotConfigured
+		return c
+	}
+	if !shell.HasIntegration(env.ShellType) {
+		c.Status, c.Message = StatusFail, fmt.Sprintf(ui.Msg.DoctorRCMissing, configFile)
+		c.Fix = fmt.Sprintf(ui.Msg.DoctorFixAddRCLine, configFile, line)
+		return c
+	}
+	c.Status, c.Message = StatusOK, fmt.Sprintf(ui.Msg.DoctorRCOK, configFile)
+	return c
+}
+
+// checkInitialized verifies that Config.Initialized agrees with the rc file.
+func checkInitialized(env Environment) Check {
+	c := Check{Name: "initialized"}
+	integrated := shell.HasIntegration(env.ShellType)
+	switch {
+	case env.Initialized && !integrated:
+		c.Status, c.Message, c.Fix = StatusWarn, ui.Msg.DoctorInitializedMismatch, ui.Msg.DoctorFixRunSetup
+	case !env.Initialized && integrated:
+		c.Status, c.Message, c.Fix = StatusWarn, ui.Msg.DoctorNotInitialized, ui.Msg.DoctorFixRunSetup
+	case !env.Initialized:
+		c.Status, c.Message, c.Fix = StatusFail, ui.Msg.QuickAliasNotSetup, ui.Msg.DoctorFixRunSetup
+	default:
+		c.Status, c.Message = StatusOK, ui.Msg.DoctorInitializedOK
+	}
+	return c
+}
+
+// checkPath verifies that the rc file can find qq, and that it is the binary being run.
+func checkPath() Check {
+	c := Check{Name: "path"}
+	found, err := exec.LookPath("qq")
+	if err != nil {
+		c.Status, c.Message, c.Fix = StatusFail, ui.Msg.DoctorPathMissing, ui.Msg.DoctorFixPath
+		return c
+	}
+	if self, err := os.Executable(); err == nil && !sameFile(found, self) {
+		c.Status, c.Message, c.Fix = StatusWarn, fmt.Sprintf(ui.Msg.DoctorPathDifferent, found, self), ui.Msg.DoctorFixPathDifferent
+		return c
+	}
+	c.Status, c.Message = StatusOK, fmt.Sprintf(ui.Msg.DoctorPathOK, found)
+	return c
+}
+
+// sameFile reports whether two paths refer to the same file after resolving symlinks.
+func sameFile(a, b string) bool {
+	infoA, errA := os.Stat(a)
+	infoB, errB := os.Stat(b)
+	return errA == nil && errB == nil && os.SameFile(infoA, infoB)
+}
+
+// checkStore verifies that an alias file is readable and valid JSON. A missing file is fine.
+func checkStore(name, path, permissionFix string) Check {
+	c := Check{Name: name}
+	data, err := os.ReadFile(path)
+	switch {
+	case os.IsNotExist(err):
+		c.Status, c.Message = StatusOK, fmt.Sprintf(ui.Msg.DoctorStoreMissing, path)
+		return c
+	case err != nil:
+		c.Status, c.Message, c.Fix = StatusFail, fmt.Sprintf(ui.Msg.DoctorStoreUnreadable, path, err), permissionFix
+		return c
+	}
+
+	aliases, err := alias.DecodeAliases(path, data)
+	if err != nil {
+		c.Status, c.Message, c.Fix = StatusFail, fmt.Sprintf(ui.Msg.DoctorStoreInvalid, path, err), ui.Msg.DoctorFixStoreInvalid
+		return c
+	}
+	c.Status, c.Message = StatusOK, fmt.Sprintf(ui.Msg.DoctorStoreOK, path, len(aliases))
+	return c
+}
+
+// checkPermissions verifies that the user layer grants no more than its umask allows, so
+// aliases, backups and secrets are not readable by other users of the machine.
+func checkPermissions(env Environment) Check {
+	c := Check{Name: "user-permissions"}
+	umask := alias.DefaultUserUmask
+	if env.Umask != "" {
+		mask, err := alias.ParseUmask(env.Umask)
+		if err != nil {
+			c.Status, c.Message, c.Fix = StatusWarn, err.Error(), fmt.Sprintf(ui.Msg.DoctorFixUmask, alias.SettingUmask)
+			return c
+		}
+		umask = mask
+	}
+
+	open := alias.PermissiveFiles(env.UserConfigPath, umask)
+	if len(open) > 0 {
+		shown := open
+		if len(shown) > 5 {
+			shown = append(append([]string{}, shown[:5]...), "…")
+		}
+		c.Status, c.Message = StatusWarn, fmt.Sprintf(ui.Msg.DoctorPermissionsOpen, len(open), strings.Join(shown, ", "))
+		c.Fix = fmt.Sprintf("chmod -R %s %s", umaskSymbolic(umask), env.UserConfigPath)
+		return c
+	}
+	c.Status, c.Message = StatusOK, fmt.Sprintf(ui.Msg.DoctorPermissionsOK, env.UserConfigPath, 0777&^umask)
+	return c
+}
+
+// umaskSymbolic returns the chmod argument that takes away the permissions in umask, e.g.
+// "g-rwx,o-rwx" for 077.
+func umaskSymbolic(umask os.FileMode) string {
+	var parts []string
+	for i, who := range []string{"u", "g", "o"} {
+		bits := umask >> (3 * (2 - i)) & 7
+		if bits == 0 {
+			continue
+		}
+		perms := ""
+		for j, p := range "rwx" {
+			if bits&(4>>j) != 0 {
+				perms += string(p)
+			}
+		}
+		parts = append(parts, who+"-"+perms)
+	}
+	return strings.Join(parts, ",")
+}
+
+// checkGlobalIntegrity verifies the ownership, permissions and checksum of the global store,
+// which qq init refuses to load when they are wrong.
+func checkGlobalIntegrity(env Environment) Check {
+	c := Check{Name: "global-integrity"}
+	path := alias.StorePath(env.GlobalConfigPath)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		c.Status, c.Message = StatusSkip, fmt.Sprintf(ui.Msg.DoctorStoreMissing, path)
+		return c
+	}
+	verified, err := alias.CheckGlobalStore(path, data)
+	switch {
+	case err != nil:
+		c.Status, c.Message = StatusFail, fmt.Sprintf(ui.Msg.GlobalStoreRefused, err)
+		c.Fix = fmt.Sprintf(ui.Msg.GlobalStoreRefusedHint, path, alias.ChecksumPath(path))
+	case !verified:
+		c.Status, c.Message = StatusOK, fmt.Sprintf(ui.Msg.DoctorGlobalNoChecksum, path)
+	default:
+		c.Status, c.Message = StatusOK, fmt.Sprintf(ui.Msg.DoctorGlobalVerified, path)
+	}
+	return c
+}
+
+// checkInitSyntax runs the output of `qq init` through the shell's syntax checker (-n).
+func checkInitSyntax(env Environment) Check {
+	c := Check{Name: "init-syntax"}
+	shellPath, err := exec.LookPath(env.ShellType)
+	if env.ShellType == "" || err != nil {
+		c.Status, c.Message = StatusSkip, fmt.Sprintf(ui.Msg.DoctorInitSyntaxSkipped, env.ShellType)
+		return c
+	}
+
+	tmp, err := os.CreateTemp("", "qq-init-*")
+	if err != nil {
+		c.Status, c.Message = StatusSkip, err.Error()
+		return c
+	}
+	defer os.Remove(tmp.Name())
+	tmp.WriteString(env.InitScript)
+	tmp.Close()
+
+	out, err := exec.Command(shellPath, "-n", tmp.Name()).CombinedOutput()
+	if err != nil {
+		detail := strings.TrimSpace(strings.ReplaceAll(string(out), tmp.Name(), "qq init"))
+		c.Status, c.Message, c.Fix = StatusFail, fmt.Sprintf(ui.Msg.DoctorInitSyntaxFailed, env.ShellType, detail), ui.Msg.DoctorFixInitSyntax
+		return c
+	}
+	c.Status, c.Message = StatusOK, fmt.Sprintf(ui.Msg.DoctorInitSyntaxOK, env.ShellType)
+	return c
+}
+
+// checkShadowing reports aliases that shadow shell builtins without --allow-shadow.
+func checkShadowing(env Environment) Check {
+	c := Check{Name: "shadowing"}
+	var names []string
+	for _, f := range alias.Lint(env.UserAliases, env.GlobalAliases, env.ShellType) {
+		if f.Severity == alias.SeverityError {
+			names = append(names, f.Alias)
+		}
+	}
+	if len(names) > 0 {
+		c.Status, c.Message, c.Fix = StatusWarn, fmt.Sprintf(ui.Msg.DoctorShadowingFound, len(names), strings.Join(names, ", ")), ui.Msg.DoctorFixShadowing
+		return c
+	}
+	c.Status, c.Message = StatusOK, ui.Msg.DoctorShadowingOK
+	return c
+}
+
+// PrintReport prints the report for humans, with fixes under each failed check.
+func PrintReport(report Report) {
+	fmt.Printf("%s%s%s\n", ui.Color.Cyan+ui.Color.Bold, ui.Msg.DoctorHeader, ui.Color.Reset)
+	for _, c := range report.Checks {
+		icon, color := "✅", ui.Color.Green
+		switch c.Status {
+		case StatusSkip:
+			icon, color = "➖", ui.Color.White
+		case StatusWarn:
+			icon, color = "⚠️ ", ui.Color.Yellow
+		case StatusFail:
+			icon, color = "❌", ui.Color.Red
+		}
+		fmt.Printf(" %s%s %-18s%s %s\n", color, icon, c.Name, ui.Color.Reset, c.Message)
+		if c.Fix != "" {
+			fmt.Printf("    %s💡 %s %s%s\n", ui.Color.Cyan, ui.Msg.DoctorFixLabel, c.Fix, ui.Color.Reset)
+		}
+	}
+
+	fmt.Println()
+	if report.Problems == 0 {
+		fmt.Printf("%s✅ %s%s\n", ui.Color.Green, ui.Msg.DoctorSummaryOK, ui.Color.Reset)
+	} else {
+		fmt.Printf("%s⚠️  %s%s\n", ui.Color.Yellow, fmt.Sprintf(ui.Msg.DoctorSummaryProblems, report.Problems), ui.Color.Reset)
+	}
+}
@@ -0,0 +1,38 @@
+package shell
+
+// builtins lists the builtin commands of each supported shell.
+var builtins = map[string][]string{
+	"bash": {".", ":", "[", "alias", "bg", "bind", "break", "builtin", "caller", "cd", "command", "compgen", "complete",
+		"compopt", "continue", "declare", "dirs", "disown", "echo", "enable", "eval", "exec", "exit", "export", "false",
+		"fc", "fg", "getopts", "hash", "help", "history", "jobs", "kill", "let", "local", "logout", "mapfile", "popd",
+		"printf", "pushd", "pwd", "read", "readarray", "readonly", "return", "set", "shift", "shopt", "source",
+		"suspend", "test", "times", "trap", "true", "type", "typeset", "ulimit", "umask", "unalias", "unset", "wait"},
+	"zsh": {".", ":", "[", "alias", "autoload", "bg", "bindkey", "break", "builtin", "bye", "cd", "chdir", "command",
+		"compdef", "continue", "declare", "dirs", "disable", "disown", "echo", "emulate", "enable", "eval", "exec",
+		"exit", "export", "false", "fc", "fg", "float", "functions", "getopts", "hash", "history", "integer", "jobs",
+		"kill", "let", "local", "logout", "noglob", "popd", "print", "printf", "pushd", "pwd", "r", "read", "readonly",
+		"rehash", "return", "set", "setopt", "shift", "source", "suspend", "test", "times", "trap", "true", "type",
+		"typeset", "ulimit", "umask", "unalias", "unfunction", "unhash", "unset", "unsetopt", "wait", "whence",
+		"where", "which", "zle", "zmodload", "zstyle"},
+	"fish": {".", ":", "[", "abbr", "alias", "bg", "bind", "block", "breakpoint", "builtin", "cd", "command",
+		"commandline", "complete", "contains", "count", "dirh", "dirs", "disown", "echo", "emit", "eval", "exec",
+		"exit", "false", "fg", "fish_config", "funced", "funcsave", "functions", "history", "isatty", "jobs", "math",
+		"nextd", "prevd", "printf", "pwd", "random", "read", "realpath", "set", "set_color", "source", "status",
+		"string", "test", "true", "type", "ulimit", "wait"},
+}
+
+// IsBuiltin reports whether name is a builtin of shellType. An empty shellType
+// checks every supported shell.
+func IsBuiltin(name, shellType string) bool {
+	for shell, names := range builtins {
+		if shellType != "" && shell != shellType {
+			continue
+		}
+		for _, b := range names {
+			if b == name {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,318 @@
+package shell
+
+import (
+	"errors"
+	"strings"
+)
+
+// Kinds of definitions found by ParseDefinitions.
+const (
+	DefAlias       = "alias"        // alias name=value, or fish alias name value.
+	DefGlobalAlias = "global-alias" // zsh alias -g: expanded anywhere on the command line.
+	DefSuffixAlias = "suffix-alias" // zsh alias -s: runs files by extension.
+	DefAbbr        = "abbr"         // fish abbr: expanded while typing.
+)
+
+// Definition is an alias or abbreviation found in shell source.
+type Definition struct {
+	Name      string
+	Command   string
+	Kind      string
+	Line      int  // First line of the definition, 1-based.
+	EndLine   int  // Last line, when the command continues over several lines.
+	Supported bool // qq can manage it as a plain alias.
+	Only      bool // Lines Line..EndLine contain nothing but alias definitions.
+}
+
+// errUnterminated is returned by splitCommands when a quote is still open at the end of the input.
+var errUnterminated = errors.New("unterminated quote")
+
+// ParseDefinitions finds the alias definitions in shell source such as ~/.bashrc.
+// It understands several definitions per line (alias a='x' b='y'; alias c='z'),
+// quoting and line continuations, zsh's alias -g / -s and, when fish is true,
+// fish's `alias name value` and `abbr` forms. Anything else is ignored.
+func ParseDefinitions(text string, fish bool) []Definition {
+	return parse(text, fish, false)
+}
+
+// ParseAliasOutput finds the definitions listed by a shell's own commands: bash's `alias`
+// (alias x='...'), zsh's `alias` (x='...') or `alias -L`, and fish's `abbr --show` and
+// `functions`, whose alias functions carry their definition in --description. Indented
+// lines, such as function bodies, are ignored.
+func ParseAliasOutput(text string, fish bool) []Definition {
+	return parse(text, fish, true)
+}
+
+// parse implements ParseDefinitions and, with output set, ParseAliasOutput.
+func parse(text string, fish, output bool) []Definition {
+	var definitions []Definition
+	lines := strings.Split(text, "\n")
+	for i := 0; i < len(lines); i++ {
+		start := i
+		logical := lines[i]
+		if output && strings.TrimLeft(logical, " \t") != logical {
+			continue
+		}
+
+		// Join continuation lines and quotes left open across lines.
+		var commands [][]string
+		for {
+			if strings.HasSuffix(logical, `\`) && !strings.HasSuffix(logical, `\\`) && i+1 < len(lines) {
+				i++
+				logical = logical[:len(logical)-1] + lines[i]
+				continue
+			}
+			var err error
+			commands, err = splitCommands(logical, fish)
+			if errors.Is(err, errUnterminated) && i+1 < len(lines) {
+				i++
+				logical += "\n" + lines[i]
+				continue
+			}
+			break
+		}
+
+		var found []Definition
+		only := true
+		for _, words := range commands {
+			defined := definitionsIn(words, fish)
+			if output {
+				defined = listedDefinitions(words, fish)
+			}
+			if len(defined) == 0 {
+				only = false
+			}
+			found = append(found, defined...)
+		}
+		for _, d := range found {
+			d.Line, d.EndLine, d.Only = start+1, i+1, only
+			definitions = append(definitions, d)
+		}
+	}
+	return definitions
+}
+
+// definitionsIn returns the definitions made by a single command.
+func definitionsIn(words []string, fish bool) []Definition {
+	// Skip keywords and prefixes that may precede a command, as in `then alias x=y`.
+	for len(words) > 0 {
+		switch words[0] {
+		case "then", "do", "else", "{", "(", "builtin", "command":
+			words = words[1:]
+			continue
+		}
+		break
+	}
+	if len(words) == 0 {
+		return nil
+	}
+	switch {
+	case words[0] == "alias" && fish:
+		return fishAlias(words[1:])
+	case words[0] == "alias":
+		return posixAlias(words[1:])
+	case words[0] == "abbr" && fish:
+		return fishAbbr(words[1:])
+	}
+	return nil
+}
+
+// listedDefinitions returns the definitions in one line of ParseAliasOutput's input.
+func listedDefinitions(words []string, fish bool) []Definition {
+	switch {
+	case fish && words[0] == "function":
+		return fishFunction(words[1:])
+	case !fish && len(words) == 1:
+		// zsh lists aliases as bare name=value, even names such as "-".
+		if name, command, ok := strings.Cut(words[0], "="); ok && name != "" {
+			return []Definition{{Name: name, Command: command, Kind: DefAlias, Supported: true}}
+		}
+		return nil
+	}
+	return definitionsIn(words, fish)
+}
+
+// posixAlias parses the arguments of bash/zsh `alias`.
+func posixAlias(args []string) []Definition {
+	kind := DefAlias
+	var definitions []Definition
+	optionsDone := false
+	for _, arg := range args {
+		if !optionsDone && strings.HasPrefix(arg, "-") {
+			switch {
+			case arg == "--":
+				optionsDone = true
+			case strings.Contains(arg, "g"):
+				kind = DefGlobalAlias
+			case strings.Contains(arg, "s"):
+				kind = DefSuffixAlias
+			}
+			continue
+		}
+		optionsDone = true
+		name, command, ok := strings.Cut(arg, "=")
+		if !ok || name == "" {
+			continue // `alias name` prints a definition instead of making one.
+		}
+		definitions = append(definitions, Definition{Name: name, Command: command, Kind: kind, Supported: kind == DefAlias})
+	}
+	return definitions
+}
+
+// fishAlias parses fish's `alias name value...` and `alias name=value`.
+func fishAlias(args []string) []Definition {
+	for len(args) > 0 && strings.HasPrefix(args[0], "-") {
+		args = args[1:] // --save, -s, --
+	}
+	if len(args) == 0 {
+		return nil
+	}
+	if name, command, ok := strings.Cut(args[0], "="); ok && len(args) == 1 {
+		return []Definition{{Name: name, Command: command, Kind: DefAlias, Supported: true}}
+	}
+	if len(args) < 2 {
+		return nil
+	}
+	return []Definition{{Name: args[0], Command: strings.Join(args[1:], " "), Kind: DefAlias, Supported: true}}
+}
+
+// fishFunction parses the header of a function printed by fish's `functions`. Functions
+// made by fish's alias are described as "alias name=value" or "alias name value".
+func fishFunction(args []string) []Definition {
+	if len(args) == 0 {
+		return nil
+	}
+	name := args[0]
+	var description string
+	for i := 1; i < len(args); i++ {
+		switch {
+		case (args[i] == "--description" || args[i] == "-d") && i+1 < len(args):
+			description = args[i+1]
+		case strings.HasPrefix(args[i], "--description="):
+			description = strings.TrimPrefix(args[i], "--description=")
+		}
+	}
+	definition, ok := strings.CutPrefix(description, "alias ")
+	if !ok {
+		return nil
+	}
+	command, ok := strings.CutPrefix(definition, name+"=")
+	if !ok {
+		command, ok = strings.CutPrefix(definition, name+" ")
+	}
+	if !ok || command == "" {
+		return nil
+	}
+	return []Definition{{Name: name, Command: command, Kind: DefAlias, Supported: true}}
+}
+
+// fishAbbr parses `abbr [-a] [options] [--] name expansion...`. Queries such as
+// abbr --list or --erase, and abbreviations that run a function, define nothing.
+func fishAbbr(args []string) []Definition {
+	var positional []string
+	kind := DefAbbr
+	optionsDone := false
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if optionsDone || !strings.HasPrefix(arg, "-") {
+			positional = append(positional, arg)
+			continue
+		}
+		switch arg {
+		case "--":
+			optionsDone = true
+		case "-a", "--add", "-g", "--global", "-U", "--universal":
+		case "--position", "-p":
+			if i+1 < len(args) && args[i+1] == "anywhere" {
+				kind = DefGlobalAlias // Expanded anywhere on the line, like zsh's alias -g.
+			}
+			i++
+		case "--regex", "-r", "--set-cursor", "--command", "-c":
+			i++ // Option with a separate value.
+		case "-f", "--function", "-e", "--erase", "-l", "--list", "-s", "--show", "-q", "--query", "--rename":
+			return nil
+		default:
+			if strings.HasPrefix(arg, "--function") {
+				return nil
+			}
+		}
+	}
+	if len(positional) < 2 {
+		return nil
+	}
+	return []Definition{{Name: positional[0], Command: strings.Join(positional[1:], " "), Kind: kind, Supported: kind == DefAbbr}}
+}
+
+// splitCommands splits one logical line into commands (separated by ;, &, |, && or ||)
+// made of unquoted words. Comments are dropped. fish allows \' and \\ inside single quotes.
+func splitCommands(line string, fish bool) ([][]string, error) {
+	var commands [][]string
+	var words []string
+	var word strings.Builder
+	inWord := false
+	flushWord := func() {
+		if inWord {
+			words = append(words, word.String())
+			word.Reset()
+			inWord = false
+		}
+	}
+	flushCommand := func() {
+		flushWord()
+		if len(words) > 0 {
+			commands = append(commands, words)
+			words = nil
+		}
+	}
+
+	runes := []rune(line)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == '\'':
+			inWord = true
+			for i++; ; i++ {
+				if i >= len(runes) {
+					return nil, errUnterminated
+				}
+				if fish && runes[i] == '\\' && i+1 < len(runes) && (runes[i+1] == '\'' || runes[i+1] == '\\') {
+					i++
+				} else if runes[i] == '\'' {
+					break
+				}
+				word.WriteRune(runes[i])
+			}
+		case r == '"':
+			inWord = true
+			for i++; ; i++ {
+				if i >= len(runes) {
+					return nil, errUnterminated
+				}
+				if runes[i] == '\\' && i+1 < len(runes) && strings.ContainsRune("\"\\$`", runes[i+1]) {
+					i++
+				} else if runes[i] == '"' {
+					break
+				}
+				word.WriteRune(runes[i])
+			}
+		case r == '\\':
+			inWord = true
+			if i+1 < len(runes) {
+				i++
+				word.WriteRune(runes[i])
+			}
+		case r == '#' && !inWord:
+			flushCommand()
+			return commands, nil
+		case r == ' ' || r == '\t' || r == '\n':
+			flushWord()
+		case r == ';' || r == '&' || r == '|':
+			flushCommand()
+		default:
+			inWord = true
+			word.WriteRune(r)
+		}
+	}
+	flushCommand()
+	return commands, nil
+}
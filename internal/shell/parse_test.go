@@ -0,0 +1,88 @@
+package shell
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// summary describes a definition on one line, for compact tables.
+func summary(d Definition) string {
+	s := fmt.Sprintf("%s %s=%s lines %d-%d", d.Kind, d.Name, d.Command, d.Line, d.EndLine)
+	if d.Only {
+		s += " only"
+	}
+	if !d.Supported {
+		s += " unsupported"
+	}
+	return s
+}
+
+func summaries(definitions []Definition) []string {
+	var s []string
+	for _, d := range definitions {
+		s = append(s, summary(d))
+	}
+	return s
+}
+
+func TestParseDefinitions(t *testing.T) {
+	tests := []struct {
+		name, text string
+		fish       bool
+		want       []string
+	}{
+		{"single quotes", "alias ll='ls -la'\n", false, []string{"alias ll=ls -la lines 1-1 only"}},
+		{"double quotes and escapes", `alias gl="git log --format=\"%h \$x\""`, false, []string{`alias gl=git log --format="%h $x" lines 1-1 only`}},
+		{"escaped quote", `alias it='echo it'\''s'`, false, []string{"alias it=echo it's lines 1-1 only"}},
+		{"several per line", "alias a='x' b=y; alias c='z'\n", false,
+			[]string{"alias a=x lines 1-1 only", "alias b=y lines 1-1 only", "alias c=z lines 1-1 only"}},
+		{"mixed with other commands", "export X=1; alias a=x\n", false, []string{"alias a=x lines 1-1"}},
+		{"comment", "# alias no=x\nalias a=x # alias b=y\n", false, []string{"alias a=x lines 2-2 only"}},
+		{"continuation", "alias a='x' \\\n  b='y'\n", false, []string{"alias a=x lines 1-2 only", "alias b=y lines 1-2 only"}},
+		{"quote over lines", "alias a='echo 1\necho 2'\nalias b=y\n", false,
+			[]string{"alias a=echo 1\necho 2 lines 1-2 only", "alias b=y lines 3-3 only"}},
+		{"inside if", "if true; then alias a=x; fi\n", false, []string{"alias a=x lines 1-1"}},
+		{"zsh global and suffix", "alias -g G='| grep'\nalias -s txt=vim\n", false,
+			[]string{"global-alias G=| grep lines 1-1 only unsupported", "suffix-alias txt=vim lines 2-2 only unsupported"}},
+		{"printing an alias", "alias ll\n", false, nil},
+		{"fish alias", "alias ll 'ls -la'\nalias gs=\"git status\"\nalias --save k kubectl get\n", true,
+			[]string{"alias ll=ls -la lines 1-1 only", "alias gs=git status lines 2-2 only", "alias k=kubectl get lines 3-3 only"}},
+		{"fish quotes", `alias e 'echo \'a\' \\ b'`, true, []string{`alias e=echo 'a' \ b lines 1-1 only`}},
+		{"fish abbr", "abbr -a gco git checkout\nabbr --position anywhere L '| less'\nabbr --erase gco\nabbr -a --function f x\n", true,
+			[]string{"abbr gco=git checkout lines 1-1 only", "global-alias L=| less lines 2-2 only unsupported"}},
+		{"abbr outside fish", "abbr -a gco git checkout\n", false, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := summaries(ParseDefinitions(tt.text, tt.fish)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got  %q\nwant %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseAliasOutput(t *testing.T) {
+	tests := []struct {
+		name, text string
+		fish       bool
+		want       []string
+	}{
+		{"bash", "alias ll='ls -la'\nalias it='echo it'\\''s'\n", false,
+			[]string{"alias ll=ls -la lines 1-1 only", "alias it=echo it's lines 2-2 only"}},
+		{"zsh", "-='cd -'\nll='ls -la'\ngs=git\\ status\n", false,
+			[]string{"alias -=cd - lines 1-1 only", "alias ll=ls -la lines 2-2 only", "alias gs=git status lines 3-3 only"}},
+		{"zsh alias -L", "alias -g G='| grep'\nalias ll='ls -la'\n", false,
+			[]string{"global-alias G=| grep lines 1-1 only unsupported", "alias ll=ls -la lines 2-2 only"}},
+		{"fish functions", "function ll --wraps='ls -la' --description 'alias ll=ls -la'\n  ls -la $argv\nend\nfunction gs --description='alias gs git status'\n  git status $argv\nend\nfunction f --description 'my function'\nend\n", true,
+			[]string{"alias ll=ls -la lines 1-1 only", "alias gs=git status lines 4-4 only"}},
+		{"fish abbr --show", "abbr -a -- gco 'git checkout'\n", true, []string{"abbr gco=git checkout lines 1-1 only"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := summaries(ParseAliasOutput(tt.text, tt.fish)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got  %q\nwant %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package shell
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Quote returns s quoted for a POSIX shell. Words made only of safe characters are
+// returned unchanged; everything else is wrapped in single quotes.
+func Quote(s string) string {
+	if s == "" {
+		return "''"
+	}
+	if strings.IndexFunc(s, needsQuoting) < 0 {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// JoinArgs joins an argument vector back into a command line, quoting arguments
+// as needed so that the shell splits it into the same words again.
+func JoinArgs(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = Quote(arg)
+	}
+	return strings.Join(quoted, " ")
+}
+
+// needsQuoting reports whether r has a special meaning to the shell.
+func needsQuoting(r rune) bool {
+	switch {
+	case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		return false
+	}
+	return !strings.ContainsRune("-_./:=@%+,", r)
+}
+
+// QuoteFor returns s as a single-quoted string literal for shellType.
+// fish treats backslashes inside single quotes as escapes, POSIX shells do not.
+func QuoteFor(shellType, s string) string {
+	if shellType == "fish" {
+		return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s) + "'"
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// AliasLine returns the line that defines an alias in shellType, as emitted by `qq init`.
+func AliasLine(shellType, name, command string) string {
+	if shellType == "fish" {
+		return fmt.Sprintf("alias %s %s", name, QuoteFor(shellType, command))
+	}
+	return fmt.Sprintf("alias %s=%s", name, QuoteFor(shellType, command))
+}
@@ -6,6 +6,8 @@ import (
 	"os/user"
 	"path/filepath"
 	"strings"
+
+	"quickalias/internal/ui"
 )
 
 // QuickAliasConfig is an interface that defines the methods needed from the QuickAlias
@@ -32,40 +34,65 @@ func DetectShell() string {
 	return "" // Return empty string if shell is not recognized.
 }
 
-// AddShellIntegration adds a line to the shell's configuration file to source QuickAlias's init script.
-// colorGreen, colorYellow, colorReset parametreleri dışarıdan alınacak.
-func AddShellIntegration(qaConfig QuickAliasConfig, colorGreen, colorYellow, colorReset string) error {
-	currentUser, _ := user.Current() // Get current user's home directory.
-	var configFile string
-	var integrationLine string
-
-	shellType := qaConfig.GetShellType()
-	if shellType == "" {
-		shellType = DetectShell() // Detect shell if not already set.
-		qaConfig.SetShellType(shellType)
+// ConfigFile returns the shell's configuration file and the line that loads QuickAlias from it.
+func ConfigFile(shellType string) (string, string, error) {
+	currentUser, err := user.Current() // Get current user's home directory.
+	if err != nil {
+		return "", "", err
 	}
 
 	// Determine the correct configuration file and integration line based on shell type.
 	switch shellType {
 	case "bash":
-		configFile = filepath.Join(currentUser.HomeDir, ".bashrc")
-		integrationLine = "eval \"$(qq init)\""
+		return filepath.Join(currentUser.HomeDir, ".bashrc"), "eval \"$(qq init)\"", nil
 	case "zsh":
-		configFile = filepath.Join(currentUser.HomeDir, ".zshrc")
-		integrationLine = "eval \"$(qq init)\""
+		return filepath.Join(currentUser.HomeDir, ".zshrc"), "eval \"$(qq init)\"", nil
 	case "fish":
-		configDir := filepath.Join(currentUser.HomeDir, ".config/fish")
-		os.MkdirAll(configDir, 0755) // Ensure Fish config directory exists.
-		configFile = filepath.Join(configDir, "config.fish")
-		integrationLine = "qq init | source" // Fish uses 'source' differently.
-	default:
-		return fmt.Errorf("Desteklenmeyen kabuk: %s", shellType)
+		return filepath.Join(currentUser.HomeDir, ".config/fish", "config.fish"), "qq init | source", nil // Fish uses 'source' differently.
+	}
+	return "", "", fmt.Errorf("Desteklenmeyen kabuk: %s", shellType)
+}
+
+// HasIntegration reports whether the shell's configuration file already loads QuickAlias.
+func HasIntegration(shellType string) bool {
+	configFile, integrationLine, err := ConfigFile(shellType)
+	if err != nil {
+		return false
+	}
+	data, err := os.ReadFile(configFile)
+	return err == nil && strings.Contains(string(data), integrationLine)
+}
+
+// AdoptFunction returns the qq-adopt shell function emitted by `qq init`. It pipes the
+// aliases defined in the running shell, which qq cannot see itself, into `qq adopt`.
+func AdoptFunction(shellType string) string {
+	switch shellType {
+	case "zsh":
+		return "qq-adopt() { alias -L | command qq adopt \"$@\"; }"
+	case "fish":
+		return "function qq-adopt; begin; functions (functions --names); abbr --show; end | command qq adopt $argv; end"
+	}
+	return "qq-adopt() { alias | command qq adopt \"$@\"; }"
+}
+
+// AddShellIntegration adds a line to the shell's configuration file to source QuickAlias's init script.
+func AddShellIntegration(qaConfig QuickAliasConfig) error {
+	shellType := qaConfig.GetShellType()
+	if shellType == "" {
+		shellType = DetectShell() // Detect shell if not already set.
+		qaConfig.SetShellType(shellType)
+	}
+
+	configFile, integrationLine, err := ConfigFile(shellType)
+	if err != nil {
+		return err
 	}
+	os.MkdirAll(filepath.Dir(configFile), 0755) // Ensure the config directory exists (e.g. ~/.config/fish).
 
 	// Check if the integration line already exists in the config file to prevent duplicates.
 	if data, err := os.ReadFile(configFile); err == nil {
 		if strings.Contains(string(data), integrationLine) {
-			fmt.Printf("%s⚠️ Kabuk entegrasyonu zaten mevcut.%s\n", colorYellow, colorReset)
+			fmt.Printf("%s⚠️ Kabuk entegrasyonu zaten mevcut.%s\n", ui.Color.Yellow, ui.Color.Reset)
 			return nil // Already integrated, no action needed.
 		}
 	}
@@ -83,6 +110,6 @@ func AddShellIntegration(qaConfig QuickAliasConfig, colorGreen, colorYellow, col
 		return fmt.Errorf("Kabuk yapılandırma dosyasına yazılamıyor: %w", err)
 	}
 
-	fmt.Printf("%s✅ Kabuk entegrasyonu eklendi: %s%s\n", colorGreen, configFile, colorReset)
+	fmt.Printf("%s✅ Kabuk entegrasyonu eklendi: %s%s\n", ui.Color.Green, configFile, ui.Color.Reset)
 	return nil
 }
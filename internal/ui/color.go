@@ -0,0 +1,81 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+)
+
+// Colour modes accepted by --color.
+const (
+	ColorAuto   = "auto"
+	ColorAlways = "always"
+	ColorNever  = "never"
+)
+
+// theme holds the escape sequences used for coloured output.
+// When colours are disabled every field is empty, so callers can concatenate them unconditionally.
+type theme struct {
+	Red    string
+	Green  string
+	Yellow string
+	Blue   string
+	Purple string
+	Cyan   string
+	White  string
+	Reset  string
+	Bold   string
+	Dim    string
+}
+
+var Color *theme // Global variable to hold the active colour theme, set by SetColorMode.
+
+// ansiTheme returns the ANSI colour codes for better UX.
+func ansiTheme() *theme {
+	return &theme{
+		Red:    "\033[31m",
+		Green:  "\033[32m",
+		Yellow: "\033[33m",
+		Blue:   "\033[34m",
+		Purple: "\033[35m",
+		Cyan:   "\033[36m",
+		White:  "\033[37m",
+		Reset:  "\033[0m",
+		Bold:   "\033[1m",
+		Dim:    "\033[2m",
+	}
+}
+
+// SetColorMode selects the colour theme for "auto", "always" or "never".
+// In auto mode NO_COLOR disables colours, CLICOLOR_FORCE enables them,
+// and otherwise colours are used only when stdout is a terminal.
+func SetColorMode(mode string) error {
+	var enabled bool
+	switch mode {
+	case ColorAlways:
+		enabled = true
+	case ColorNever:
+		enabled = false
+	case ColorAuto, "":
+		enabled = colorsWanted()
+	default:
+		return fmt.Errorf(Msg.InvalidColorMode, mode)
+	}
+
+	if enabled {
+		Color = ansiTheme()
+	} else {
+		Color = &theme{}
+	}
+	return nil
+}
+
+// colorsWanted applies the NO_COLOR / CLICOLOR_FORCE conventions and falls back to tty detection.
+func colorsWanted() bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	if force := os.Getenv("CLICOLOR_FORCE"); force != "" && force != "0" {
+		return true
+	}
+	return IsTerminal(os.Stdout)
+}
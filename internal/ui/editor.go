@@ -0,0 +1,24 @@
+package ui
+
+import (
+	"os"
+	"os/exec"
+)
+
+// Editor returns the user's editor from $VISUAL or $EDITOR, falling back to vi.
+func Editor() string {
+	for _, name := range []string{"VISUAL", "EDITOR"} {
+		if editor := os.Getenv(name); editor != "" {
+			return editor
+		}
+	}
+	return "vi"
+}
+
+// OpenEditor opens path in the user's editor and waits for it to exit.
+// The editor setting is run through sh so that values such as "code --wait" work.
+func OpenEditor(path string) error {
+	cmd := exec.Command("sh", "-c", Editor()+` "$1"`, "sh", path)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	return cmd.Run()
+}
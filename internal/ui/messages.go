@@ -5,28 +5,8 @@ import (
 	"strings"
 )
 
-// Color codes for better UX
-const (
-	ColorRed    = "\033[31m"
-	ColorGreen  = "\033[32m"
-	ColorYellow = "\033[33m"
-	ColorBlue   = "\033[34m"
-	ColorPurple = "\033[35m"
-	ColorCyan   = "\033[36m"
-	ColorWhite  = "\033[37m"
-	ColorReset  = "\033[0m"
-	ColorBold   = "\033[1m"
-)
-
 // messages holds all translatable strings for the CLI output.
 type messages struct {
-	AddAliasUsage                  string
-	SetAliasUsage                  string
-	RemoveAliasUsage               string
-	UnsetAliasUsage                string
-	SearchAliasUsage               string
-	ConfigSubcommandRequired       string
-	UnknownConfigSubcommand        string
 	QuickAliasNotSetup             string
 	RunSetupTip                    string
 	UnknownCommand                 string
@@ -60,6 +40,7 @@ type messages struct {
 	TotalResultsFound              string
 	QuickAliasStatus               string
 	UserAliasesCount               string
+	PackAliasesCount               string
 	GlobalAliasesCount             string
 	UserGlobalConflicts            string
 	ConflictsHint                  string
@@ -99,6 +80,7 @@ type messages struct {
 	UsageSystem                    string
 	UsageConfiguration             string
 	UsageOther                     string
+	UsageGlobalOptions             string
 	TipsHeader                     string
 	TipRunSetupFirst               string
 	TipUserOverridesGlobal         string
@@ -116,6 +98,351 @@ type messages struct {
 	MainConfigFileRemovePrompt   string
 	ErrorFileSudoRemovalFailed   string
 	ErrorFileRemovalFailed       string
+	// Non-interactive mode messages
+	ConfirmationRequired string
+	// Output format messages
+	FlagNeedsValue         string
+	InvalidFormatTemplate  string
+	FormatNotSupported     string
+	ConflictingFormatFlags string
+	// Colour messages
+	InvalidColorMode string
+	// Command summaries
+	CmdAddSummary                   string
+	CmdSetSummary                   string
+	CmdRemoveSummary                string
+	CmdUnsetSummary                 string
+	CmdListSummary                  string
+	CmdSearchSummary                string
+	CmdStatusSummary                string
+	CmdSetupSummary                 string
+	CmdInitSummary                  string
+	CmdConfigSummary                string
+	CmdConfigResetSummary           string
+	CmdConfigBackupSummary          string
+	CmdConfigExportSummary          string
+	CmdConfigImportSummary          string
+	CmdCompletionSummary            string
+	CmdVersionSummary               string
+	CmdHelpSummary                  string
+	FlagYesSummary                  string
+	FlagNoInputSummary              string
+	FlagJSONSummary                 string
+	FlagFormatSummary               string
+	FlagColorSummary                string
+	ErrorUnsupportedCompletionShell string
+	// Command framework messages
+	UsageHeader            string
+	HelpFlagsPlaceholder   string
+	HelpAliases            string
+	HelpSubcommands        string
+	HelpFlags              string
+	HelpHint               string
+	TipCommandHelp         string
+	FlagForceSummary       string
+	UnknownFlag            string
+	UnknownSubcommand      string
+	SubcommandRequired     string
+	NotEnoughArguments     string
+	TooManyArguments       string
+	InvalidAliasDefinition string
+	CmdAddDescription      string
+	HelpOverviewHint       string
+	// Validation messages
+	InvalidAliasNameEmpty           string
+	InvalidAliasNameWhitespace      string
+	InvalidAliasNameChar            string
+	InvalidAliasNameDash            string
+	InvalidAliasNameReserved        string
+	WarningCommandEmpty             string
+	WarningCommandUnbalanced        string
+	WarningCommandTrailingBackslash string
+	WarningCommandPrefix            string
+	ImportValidationFailed          string
+	// Shadowing messages
+	ShadowingHeader        string
+	ShadowsAlias           string
+	ShadowsBuiltin         string
+	ShadowsCommand         string
+	ShadowAllowedMarker    string
+	ShadowBlocked          string
+	FlagAllowShadowSummary string
+	// Doctor messages
+	CmdDoctorSummary          string
+	DoctorHeader              string
+	DoctorShellNotConfigured  string
+	DoctorFixRunSetup         string
+	DoctorShellOK             string
+	DoctorShellMissing        string
+	DoctorRCOK                string
+	DoctorRCMissing           string
+	DoctorFixAddRCLine        string
+	DoctorInitializedMismatch string
+	DoctorNotInitialized      string
+	DoctorInitializedOK       string
+	DoctorPathMissing         string
+	DoctorFixPath             string
+	DoctorPathOK              string
+	DoctorPathDifferent       string
+	DoctorFixPathDifferent    string
+	DoctorStoreMissing        string
+	DoctorStoreOK             string
+	DoctorStoreUnreadable     string
+	DoctorStoreInvalid        string
+	DoctorFixStoreInvalid     string
+	DoctorInitSyntaxOK        string
+	DoctorInitSyntaxFailed    string
+	DoctorFixInitSyntax       string
+	DoctorInitSyntaxSkipped   string
+	DoctorShadowingOK         string
+	DoctorShadowingFound      string
+	DoctorFixShadowing        string
+	DoctorFixLabel            string
+	DoctorSummaryOK           string
+	DoctorSummaryProblems     string
+	DoctorProblemsFound       string
+	// Metadata messages
+	FlagDescSummary         string
+	FlagTagSummary          string
+	FlagCategorySummary     string
+	FlagListTagSummary      string
+	FlagListCategorySummary string
+	// Provenance messages
+	InvalidSortKey       string
+	FlagSortSummary      string
+	CmdShowSummary       string
+	AliasNotDefined      string
+	ShowEffectiveMarker  string
+	ShowCommandLabel     string
+	ShowDescriptionLabel string
+	ShowCategoryLabel    string
+	ShowTagsLabel        string
+	ShowCreatedLabel     string
+	ShowUpdatedLabel     string
+	ShowCreatedByLabel   string
+	ShowHostLabel        string
+	ShowAllowShadowLabel string
+	ShowYes              string
+	// Resolution messages
+	CmdWhichSummary      string
+	WhichOverridesHeader string
+	WhichInitLineHeader  string
+	// Edit messages
+	CmdEditSummary           string
+	CmdEditDescription       string
+	EditFileHeader           string
+	EditMetadataWithoutAlias string
+	EditInvalidLine          string
+	EditUnknownKey           string
+	EditDuplicateAlias       string
+	EditOpeningEditor        string
+	EditEditorFailed         string
+	EditReopenPrompt         string
+	EditNoChanges            string
+	EditSaveConfirmation     string
+	EditSaved                string
+	DiffMetadataChanged      string
+	EditShadowBlocked        string
+	// Rename, copy and move messages
+	CmdRenameSummary  string
+	CmdCopySummary    string
+	CmdPromoteSummary string
+	CmdDemoteSummary  string
+	FlagGlobalSummary string
+	SameAliasName     string
+	AliasRenamed      string
+	AliasCopied       string
+	AliasMoved        string
+	// Enable/disable messages
+	CmdDisableSummary   string
+	CmdEnableSummary    string
+	AliasDisabled       string
+	AliasEnabled        string
+	AliasStateUnchanged string
+	DisabledMarker      string
+	StateEnabled        string
+	StateDisabled       string
+	ShowStateLabel      string
+	WhichDisabledHeader string
+	WhichAllDisabled    string
+	// Condition messages
+	InvalidHostPattern   string
+	ReasonHost           string
+	ReasonOS             string
+	ReasonShell          string
+	ReasonCommand        string
+	ReasonEnvUnset       string
+	ReasonEnvValue       string
+	InactiveMarker       string
+	ShowConditionsLabel  string
+	ShowInactiveLabel    string
+	WhichInactiveHeader  string
+	WhichNoneActive      string
+	FlagIfHostSummary    string
+	FlagIfOSSummary      string
+	FlagIfShellSummary   string
+	FlagIfCommandSummary string
+	FlagIfEnvSummary     string
+	// Import from shell files
+	CmdImportShellSummary             string
+	CmdImportShellDescription         string
+	FlagOverwriteSummary              string
+	FlagCommentOutSummary             string
+	ImportShellNothingFound           string
+	ImportShellFound                  string
+	ImportShellLine                   string
+	ImportShellOverwrites             string
+	ImportShellManaged                string
+	ImportShellConflict               string
+	ImportShellSkipGlobal             string
+	ImportShellSkipSuffix             string
+	ImportShellSkipMultiline          string
+	ImportShellSkipRedefined          string
+	ImportShellSkipShortSecret        string
+	ImportShellNothingToDo            string
+	ImportShellConfirmation           string
+	ImportShellConfirmationCommentOut string
+	ImportShellImported               string
+	ImportShellCommentedOut           string
+	ImportShellMigratedMarker         string
+	// Adopting shell aliases
+	CmdAdoptSummary       string
+	CmdAdoptDescription   string
+	FlagAdoptAllSummary   string
+	FlagAdoptShellSummary string
+	AdoptNeedsInput       string
+	AdoptNothingFound     string
+	AdoptFound            string
+	AdoptManaged          string
+	AdoptSelectPrompt     string
+	AdoptInvalidPick      string
+	AdoptAdopted          string
+	AdoptOriginalHint     string
+	// Export formats
+	CmdConfigExportDescription string
+	FlagExportFormatSummary    string
+	UnknownExportFormat        string
+	// YAML and TOML stores
+	StoreLoadWarning          string
+	StoreInvalidName          string
+	StoreUnreadableRefuseSave string
+	// Apply manifests
+	CmdApplySummary       string
+	CmdApplyDescription   string
+	FlagApplyCheckSummary string
+	FlagApplyPruneSummary string
+	ApplyNoChanges        string
+	ApplyUnmanagedKept    string
+	ApplyLevelNotPruned   string
+	ApplyUpToDate         string
+	ApplyDrift            string
+	ApplyConfirmation     string
+	ApplyApplied          string
+	ApplyDuplicate        string
+	ApplyUnknownLevel     string
+	// Packs
+	CmdPackSummary             string
+	CmdPackDescription         string
+	CmdPackInstallSummary      string
+	CmdPackListSummary         string
+	CmdPackRemoveSummary       string
+	PackAliasesHeader          string
+	NoPackAliasesMatch         string
+	PackAliasOverridden        string
+	PackAliasOverriddenBy      string
+	UserAliasRemovedPackActive string
+	PackManifestNotMapping     string
+	PackInvalidName            string
+	PackManifestNotFound       string
+	PackManifestTooLarge       string
+	PackLoadWarning            string
+	PackWriteError             string
+	PackRemoveError            string
+	PackReadError              string
+	PackUpToDate               string
+	PackInstallConfirmation    string
+	PackReplaceConfirmation    string
+	PackInstalled              string
+	PackOverriddenByUser       string
+	PackOverriddenByPack       string
+	PacksNotInstalled          string
+	PackAliasCount             string
+	PackOverriddenCount        string
+	PackNotInstalled           string
+	PackRemoveConfirmation     string
+	PackRemoved                string
+	PackNoVersion              string
+	// Pack sources
+	CmdPackUpdateSummary        string
+	CmdPackAddSourceSummary     string
+	CmdPackAddSourceDescription string
+	FlagPackKeySummary          string
+	PackInvalidPublicKey        string
+	PackNoTrustedKeys           string
+	PackSignatureMalformed      string
+	PackSignatureInvalid        string
+	PackSignatureUnknownKey     string
+	PackIndexParseError         string
+	PackInvalidRelease          string
+	PackVerificationFailed      string
+	PackUnsupportedURL          string
+	PackDownloadFailed          string
+	PackDownloadTooLarge        string
+	PackNoSources               string
+	PackSourceUnavailable       string
+	PackNotInSources            string
+	PackReleaseMismatch         string
+	PackReleaseUnversioned      string
+	PackSignatureVerified       string
+	PackSourceAdded             string
+	PackSourceExists            string
+	PackKeyTrusted              string
+	PackTrustHint               string
+	PackNotFromSource           string
+	PacksUpToDate               string
+	// Secrets
+	WarningCommandSecret   string
+	SecretNotFound         string
+	SecretAliasSkipped     string
+	InitAliasSkipped       string
+	SecretNotUserLevel     string
+	SecretAliasRefused     string
+	SecretFileTooOpen      string
+	SecretInvalidName      string
+	SecretPrompt           string
+	SecretEmpty            string
+	SecretMultiline        string
+	SecretWriteError       string
+	SecretSaved            string
+	SecretReplaced         string
+	SecretUsageHint        string
+	SecretNotInFile        string
+	SecretRemoved          string
+	SecretStillUsed        string
+	SecretsNone            string
+	SecretInFile           string
+	SecretExternal         string
+	SecretUnused           string
+	SecretUsedBy           string
+	CmdSecretSummary       string
+	CmdSecretDescription   string
+	CmdSecretSetSummary    string
+	CmdSecretListSummary   string
+	CmdSecretRemoveSummary string
+	// Permissions
+	InvalidUmask          string
+	DoctorPermissionsOK   string
+	DoctorPermissionsOpen string
+	DoctorFixUmask        string
+	// Global store integrity
+	GlobalStoreWorldWritable    string
+	GlobalStoreNotRootOwned     string
+	GlobalStoreChecksumInvalid  string
+	GlobalStoreChecksumMismatch string
+	GlobalStoreRefused          string
+	GlobalStoreRefusedHint      string
+	DoctorGlobalVerified        string
+	DoctorGlobalNoChecksum      string
 }
 
 var Msg *messages // Global variable to hold the current language messages, changed to capitalized for export.
@@ -136,18 +463,12 @@ func init() {
 		locale = os.Getenv("LANG")
 	}
 	LoadMessages(locale)
+	SetColorMode(ColorAuto)
 }
 
 // These functions contain the localized message sets.
 func loadTurkishMessages() *messages {
 	return &messages{
-		AddAliasUsage:                "Kullanım: qq add <alias> \"<komut>\"",
-		SetAliasUsage:                "Kullanım: qq set <alias> \"<komut>\" (Global alias ekle)",
-		RemoveAliasUsage:             "Kullanım: qq remove <alias>",
-		UnsetAliasUsage:              "Kullanım: qq unset <alias> (Global alias kaldır)",
-		SearchAliasUsage:             "Kullanım: qq search <anahtar_kelime>",
-		ConfigSubcommandRequired:     "Yapılandırma komutu için alt komut gerekli.",
-		UnknownConfigSubcommand:      "Bilinmeyen yapılandırma alt komutu: %s",
 		QuickAliasNotSetup:           "QuickAlias kurulumu yapılmamış görünüyor!",
 		RunSetupTip:                  "Lütfen QuickAlias'ı yapılandırmak için '%sqq setup%s' komutunu çalıştırın.",
 		UnknownCommand:               "Bilinmeyen komut",
@@ -179,6 +500,7 @@ func loadTurkishMessages() *messages {
 		TotalResultsFound:            "Toplam %d sonuç bulundu.",
 		QuickAliasStatus:             "QUICKALIAS DURUMU",
 		UserAliasesCount:             " %sKullanıcı Aliasları:%s %d",
+		PackAliasesCount:             " %sPaket Aliasları:%s %d (%d paket)",
 		GlobalAliasesCount:           " %sGlobal Aliaslar:%s %d",
 		UserGlobalConflicts:          " %sKullanıcı-Global Çakışmaları:%s %d",
 		ConflictsHint:                "(Çakışanlar: %s)",
@@ -215,6 +537,7 @@ func loadTurkishMessages() *messages {
 		UsageSystem:                  "Sistem:",
 		UsageConfiguration:           "Yapılandırma:",
 		UsageOther:                   "Diğer:",
+		UsageGlobalOptions:           "Genel Seçenekler:",
 		TipsHeader:                   "İPUÇLARI:",
 		TipRunSetupFirst:             "İlk çalıştırmada `qq setup` komutunu çalıştırın.",
 		TipUserOverridesGlobal:       "Kullanıcı seviyesi alias'lar, aynı isimdeki global alias'ları geçersiz kılar.",
@@ -232,18 +555,356 @@ func loadTurkishMessages() *messages {
 		MainConfigFileRemovePrompt:   "Ana yapılandırma dosyasını silmek için sudo yetkisi gerekiyor:",
 		ErrorFileSudoRemovalFailed:   "dosya sudo ile silinemedi: %s: %w",
 		ErrorFileRemovalFailed:       "dosya silinemedi: %s: %w",
+		// Non-interactive mode messages
+		ConfirmationRequired: "Bu işlem onay gerektiriyor ancak giriş okunamıyor. Onaylamak için --yes ile tekrar çalıştırın.",
+		// Output format messages
+		FlagNeedsValue:         "%s bayrağı bir değer gerektiriyor",
+		InvalidFormatTemplate:  "geçersiz --format şablonu: %w",
+		FormatNotSupported:     "bu komut '%s' çıktı biçimini desteklemiyor",
+		ConflictingFormatFlags: "--json ve --format birlikte farklı değerlerle kullanılamaz",
+		// Colour messages
+		InvalidColorMode: "geçersiz --color değeri: %s (auto, always veya never olmalı)",
+		// Command summaries
+		CmdAddSummary:                   "Kullanıcı seviye alias ekle",
+		CmdSetSummary:                   "Global alias ekle (sudo gerekli)",
+		CmdRemoveSummary:                "Kullanıcı alias kaldır",
+		CmdUnsetSummary:                 "Global alias kaldır (sudo gerekli)",
+		CmdListSummary:                  "Tüm aliasları listele veya filtrele",
+		CmdSearchSummary:                "Alias isim ve komutlarında ara",
+		CmdStatusSummary:                "Durum ve çakışmaları göster",
+		CmdSetupSummary:                 "Shell entegrasyonunu kur",
+		CmdInitSummary:                  "Aliasları başlat (shell tarafından kullanılır)",
+		CmdConfigSummary:                "Yapılandırma yönetimi",
+		CmdConfigResetSummary:           "Yapılandırmayı sıfırla",
+		CmdConfigBackupSummary:          "Mevcut yedeklemeleri göster",
+		CmdConfigExportSummary:          "Aliasları dışa aktar",
+		CmdConfigImportSummary:          "Aliasları içe aktar",
+		CmdCompletionSummary:            "Kabuk tamamlama betiği üret (bash, zsh, fish)",
+		CmdVersionSummary:               "Sürümü göster",
+		CmdHelpSummary:                  "Bu yardımı göster",
+		FlagYesSummary:                  "Tüm onay sorularını 'evet' olarak yanıtla",
+		FlagNoInputSummary:              "Asla giriş bekleme; onay gerekirse hata ver",
+		FlagJSONSummary:                 "Çıktıyı JSON olarak ver",
+		FlagFormatSummary:               "Çıktıyı TSV veya Go şablonu ile biçimlendir",
+		FlagColorSummary:                "Renkli çıktıyı denetle (auto, always, never)",
+		ErrorUnsupportedCompletionShell: "tamamlama desteklenmeyen kabuk: %s",
+		// Command framework messages
+		UsageHeader:            "KULLANIM:",
+		HelpFlagsPlaceholder:   "seçenekler",
+		HelpAliases:            "Diğer adlar:",
+		HelpSubcommands:        "Alt komutlar:",
+		HelpFlags:              "Seçenekler:",
+		HelpHint:               "Ayrıntılar için 'qq help %s' komutunu çalıştırın.",
+		TipCommandHelp:         "Bir komutun ayrıntıları için `qq help <komut>` veya `qq <komut> --help` kullanın.",
+		FlagForceSummary:       "--yes ile aynı",
+		UnknownFlag:            "bilinmeyen seçenek: %s",
+		UnknownSubcommand:      "bilinmeyen alt komut: %s",
+		SubcommandRequired:     "bu komut için alt komut gerekli",
+		NotEnoughArguments:     "eksik argüman",
+		TooManyArguments:       "fazla argüman: %s",
+		InvalidAliasDefinition: "geçersiz alias tanımı: '%s' (beklenen: <ad> <komut> veya <ad>=<komut>)",
+		CmdAddDescription:      "Komut birden fazla argüman olarak verilirse tırnaklar korunur:\n  qq add gc git commit -m \"wip fix\"\n  qq add \"ll=ls -la\"\nSeçenekler alias adından önce yazılmalıdır; addan sonraki her şey komutun parçasıdır.",
+		HelpOverviewHint:       "Tüm komutları görmek için 'qq help' komutunu çalıştırın.",
+		// Validation messages
+		InvalidAliasNameEmpty:           "alias adı boş olamaz",
+		InvalidAliasNameWhitespace:      "geçersiz alias adı '%s': boşluk içeremez",
+		InvalidAliasNameChar:            "geçersiz alias adı '%s': '%s' karakterini içeremez",
+		InvalidAliasNameDash:            "geçersiz alias adı '%s': '-' ile başlayamaz",
+		InvalidAliasNameReserved:        "geçersiz alias adı '%s': %s için ayrılmış bir sözcük",
+		WarningCommandEmpty:             "komut boş",
+		WarningCommandUnbalanced:        "komutta kapatılmamış %s tırnağı var",
+		WarningCommandTrailingBackslash: "komut ters eğik çizgi ile bitiyor",
+		WarningCommandPrefix:            "'%s': %s",
+		ImportValidationFailed:          "İçe aktarma dosyasında %d geçersiz alias var; hiçbir değişiklik yapılmadı",
+		// Shadowing messages
+		ShadowingHeader:        "GÖLGELEMELER:",
+		ShadowsAlias:           "'%s' (%s) aynı adlı %s alias'ını geçersiz kılıyor",
+		ShadowsBuiltin:         "'%s' (%s) kabuk yerleşik komutu '%s' ile çakışıyor",
+		ShadowsCommand:         "'%s' (%s) %s komutunu gölgeliyor",
+		ShadowAllowedMarker:    "(--allow-shadow)",
+		ShadowBlocked:          "'%s' bir kabuk yerleşik komutunu gölgeliyor; bilerek yapıyorsanız --allow-shadow ile tekrar deneyin",
+		FlagAllowShadowSummary: "Komut veya yerleşik komut gölgelemesine izin ver ve bunu alias'a kaydet",
+		// Doctor messages
+		CmdDoctorSummary:          "Kurulumun sağlığını denetle ve çözümler öner",
+		DoctorHeader:              "QUICKALIAS DOCTOR",
+		DoctorShellNotConfigured:  "kabuk tipi yapılandırılmamış veya desteklenmiyor",
+		DoctorFixRunSetup:         "'qq setup' komutunu çalıştırın",
+		DoctorShellOK:             "kabuk: %s",
+		DoctorShellMissing:        "%s kabuğu PATH üzerinde bulunamadı",
+		DoctorRCOK:                "%s QuickAlias'ı yüklüyor",
+		DoctorRCMissing:           "%s içinde entegrasyon satırı yok",
+		DoctorFixAddRCLine:        "'qq setup' çalıştırın veya %s dosyasına şu satırı ekleyin: %s",
+		DoctorInitializedMismatch: "yapılandırma kurulumun tamamlandığını söylüyor ancak kabuk entegrasyonu yok",
+		DoctorNotInitialized:      "kabuk entegrasyonu mevcut ancak yapılandırma kurulum yapılmadı diyor",
+		DoctorInitializedOK:       "kurulum durumu kabuk entegrasyonu ile tutarlı",
+		DoctorPathMissing:         "qq PATH üzerinde bulunamadı; kabuk başlarken 'qq init' çalıştırılamaz",
+		DoctorFixPath:             "qq'yu /usr/local/bin içine kurun veya dizinini entegrasyon satırından önce PATH'e ekleyin",
+		DoctorPathOK:              "qq PATH üzerinde: %s",
+		DoctorPathDifferent:       "PATH üzerindeki qq (%s) çalışan programdan (%s) farklı",
+		DoctorFixPathDifferent:    "eski kurulumu kaldırın veya PATH sırasını düzeltin",
+		DoctorStoreMissing:        "%s henüz yok",
+		DoctorStoreOK:             "%s: %d alias",
+		DoctorStoreUnreadable:     "%s okunamıyor: %v",
+		DoctorStoreInvalid:        "%s okunamıyor: %v",
+		DoctorFixStoreInvalid:     "dosyayı düzeltin veya bir yedeği geri yükleyin (qq config backup)",
+		DoctorInitSyntaxOK:        "'qq init' çıktısı %s tarafından ayrıştırılabiliyor",
+		DoctorInitSyntaxFailed:    "'qq init' çıktısı %s tarafından ayrıştırılamıyor: %s",
+		DoctorFixInitSyntax:       "hatalı alias'ı 'qq remove' ile kaldırın veya düzeltin",
+		DoctorInitSyntaxSkipped:   "'%s' kabuğu bulunamadı, sözdizimi denetimi atlandı",
+		DoctorShadowingOK:         "yerleşik komutları gölgeleyen alias yok",
+		DoctorShadowingFound:      "%d alias kabuk yerleşik komutlarını gölgeliyor: %s",
+		DoctorFixShadowing:        "alias'ı yeniden adlandırın veya bilerek yapıyorsanız --allow-shadow ile tekrar ekleyin",
+		DoctorFixLabel:            "Çözüm:",
+		DoctorSummaryOK:           "Sorun bulunamadı.",
+		DoctorSummaryProblems:     "%d sorun bulundu.",
+		DoctorProblemsFound:       "doctor %d sorun buldu",
+		// Metadata messages
+		FlagDescSummary:         "Alias'ın ne işe yaradığını açıklayan metin",
+		FlagTagSummary:          "Etiket ekle (tekrarlanabilir veya virgülle ayrılmış)",
+		FlagCategorySummary:     "'qq list' çıktısında gruplanacağı kategori",
+		FlagListTagSummary:      "Yalnızca bu etikete sahip alias'ları göster (tekrarlanabilir)",
+		FlagListCategorySummary: "Yalnızca bu kategorideki alias'ları göster",
+		// Provenance messages
+		InvalidSortKey:       "geçersiz sıralama anahtarı '%s' (name, created veya updated olmalı)",
+		FlagSortSummary:      "Sırala: name (alfabetik), created veya updated (en yeni önce)",
+		CmdShowSummary:       "Bir alias'ın ayrıntılarını ve kaynağını göster",
+		AliasNotDefined:      "'%s' adında bir alias yok",
+		ShowEffectiveMarker:  "(geçerli)",
+		ShowCommandLabel:     "Komut:",
+		ShowDescriptionLabel: "Açıklama:",
+		ShowCategoryLabel:    "Kategori:",
+		ShowTagsLabel:        "Etiketler:",
+		ShowCreatedLabel:     "Oluşturulma:",
+		ShowUpdatedLabel:     "Güncellenme:",
+		ShowCreatedByLabel:   "Oluşturan:",
+		ShowHostLabel:        "Makine:",
+		ShowAllowShadowLabel: "Gölgeleme izni:",
+		ShowYes:              "evet",
+		// Resolution messages
+		CmdWhichSummary:      "Bir alias'ın nasıl çözümlendiğini ve kabuğa ne gönderildiğini açıkla",
+		WhichOverridesHeader: "GEÇERSİZ KILINAN TANIMLAR:",
+		WhichInitLineHeader:  "'qq init' ÇIKTISI (%s):",
+		// Edit messages
+		CmdEditSummary:           "Kullanıcı alias'larını $EDITOR ile düzenle",
+		CmdEditDescription:       "Kullanıcı alias'larını (veya yalnızca verilen alias'ı) geçici bir dosyada $VISUAL/$EDITOR ile açar. Kaydedip çıktığınızda dosya doğrulanır, değişiklikler gösterilir ve onaydan sonra yedek alınarak kaydedilir. Var olmayan bir ad verilirse yeni alias için boş bir şablon açılır.",
+		EditFileHeader:           "Alias'ları düzenleyin, kaydedip çıkın. '#' ile başlayan satırlar yok sayılır.\nBiçim:   ad = komut   (çok satırlı komutlar tırnak içinde: ad = \"satır 1\\nsatır 2\")\nİsteğe bağlı girintili satırlar: desc:, tags:, category:, allow-shadow:, disabled:\nKoşullar (virgülle ayrılmış): hosts:, os:, shells:, requires:, env:\nBir alias'ı silmek için satırlarını kaldırın.",
+		EditMetadataWithoutAlias: "satır %d: girintili satır bir alias'a ait değil",
+		EditInvalidLine:          "satır %d: 'ad = komut' bekleniyordu: %s",
+		EditUnknownKey:           "satır %d: bilinmeyen alan '%s' (desc, tags, category, allow-shadow, disabled, hosts, os, shells, requires veya env olmalı)",
+		EditDuplicateAlias:       "satır %d: '%s' alias'ı %d. satırda zaten tanımlı",
+		EditOpeningEditor:        "%s ile açılıyor...",
+		EditEditorFailed:         "düzenleyici başarısız oldu: %v",
+		EditReopenPrompt:         "Düzeltmek için düzenleyici yeniden açılsın mı? [e/H]: ",
+		EditNoChanges:            "Değişiklik yok.",
+		EditSaveConfirmation:     "Bu değişiklikler kaydedilsin mi? [e/H]: ",
+		EditSaved:                "%d değişiklik kaydedildi.",
+		DiffMetadataChanged:      "(yalnızca açıklama, etiket veya kategori değişti)",
+		EditShadowBlocked:        "'%s' bir kabuk yerleşik komutunu gölgeliyor; bilerek yapıyorsanız altına 'allow-shadow: yes' ekleyin",
+		// Rename, copy and move messages
+		CmdRenameSummary:  "Bir alias'ı yeniden adlandır",
+		CmdCopySummary:    "Bir alias'ı yeni bir ada kopyala",
+		CmdPromoteSummary: "Kullanıcı alias'ını global seviyeye taşı (sudo gerekir)",
+		CmdDemoteSummary:  "Global alias'ı kullanıcı seviyesine taşı (sudo gerekir)",
+		FlagGlobalSummary: "Kullanıcı yerine global alias'lar üzerinde çalış (sudo gerekir)",
+		SameAliasName:     "yeni ad eskisiyle aynı: '%s'",
+		AliasRenamed:      "'%s' alias'ı '%s' olarak yeniden adlandırıldı (%s seviyesi).",
+		AliasCopied:       "'%s' alias'ı '%s' olarak kopyalandı (%s seviyesi).",
+		AliasMoved:        "'%s' alias'ı %s seviyesinden %s seviyesine taşındı.",
+		// Enable/disable messages
+		CmdDisableSummary:   "Bir alias'ı silmeden devre dışı bırak",
+		CmdEnableSummary:    "Devre dışı bırakılmış bir alias'ı yeniden etkinleştir",
+		AliasDisabled:       "'%s' alias'ı (%s seviyesi) devre dışı bırakıldı.",
+		AliasEnabled:        "'%s' alias'ı (%s seviyesi) etkinleştirildi.",
+		AliasStateUnchanged: "'%s' alias'ı (%s seviyesi) zaten bu durumda.",
+		DisabledMarker:      "(devre dışı)",
+		StateEnabled:        "etkin",
+		StateDisabled:       "devre dışı",
+		ShowStateLabel:      "Durum:",
+		WhichDisabledHeader: "DEVRE DIŞI TANIMLAR:",
+		WhichAllDisabled:    "Tüm tanımlar devre dışı; 'qq init' bu alias için hiçbir şey üretmez.",
+		// Condition messages
+		InvalidHostPattern:   "geçersiz makine adı deseni: '%s'",
+		ReasonHost:           "makine adı %s, %s ile eşleşmiyor",
+		ReasonOS:             "işletim sistemi %s, %s değil",
+		ReasonShell:          "kabuk %s, %s değil",
+		ReasonCommand:        "%s komutu bulunamadı",
+		ReasonEnvUnset:       "%s ortam değişkeni tanımlı değil",
+		ReasonEnvValue:       "%s ortam değişkeni %s değil",
+		InactiveMarker:       "(etkin değil: %s)",
+		ShowConditionsLabel:  "Koşullar:",
+		ShowInactiveLabel:    "Bu makinede:",
+		WhichInactiveHeader:  "BU MAKİNEDE ETKİN OLMAYAN TANIMLAR:",
+		WhichNoneActive:      "Etkin tanım yok; 'qq init' bu alias için hiçbir şey üretmez.",
+		FlagIfHostSummary:    "Yalnızca makine adı bu desenle eşleşince etkin (tekrarlanabilir)",
+		FlagIfOSSummary:      "Yalnızca bu işletim sisteminde etkin, ör. linux, darwin (tekrarlanabilir)",
+		FlagIfShellSummary:   "Yalnızca bu kabukta etkin (tekrarlanabilir)",
+		FlagIfCommandSummary: "Yalnızca bu komut PATH üzerindeyse etkin (tekrarlanabilir)",
+		FlagIfEnvSummary:     "Yalnızca VAR tanımlıysa veya VAR=değer ise etkin (tekrarlanabilir)",
+		// Import from shell files
+		CmdImportShellSummary:             "Kabuk yapılandırma dosyasındaki alias'ları içe aktar",
+		CmdImportShellDescription:         "Verilen dosyadaki (varsayılan: kabuğunuzun rc dosyası) alias tanımlarını bulur; bir satırdaki birden çok tanımı, zsh alias -g/-s ve fish alias/abbr biçimlerini anlar. Önizleme gösterir, mevcut alias'larla çakışanları işaretler ve onaydan sonra kullanıcı seviyesine ekler. --overwrite çakışan alias'ların da değiştirilmesini sağlar; --comment-out taşınan satırları kaynak dosyada yorum satırına çevirir (önce dosyanın .qq-backup yedeği alınır).",
+		FlagOverwriteSummary:              "Mevcut alias'larla çakışan tanımları da içe aktar",
+		FlagCommentOutSummary:             "Taşınan satırları kaynak dosyada yorum satırına çevir",
+		ImportShellNothingFound:           "%s içinde alias tanımı bulunamadı.",
+		ImportShellFound:                  "%[2]s içinde %[1]d alias tanımı bulundu:",
+		ImportShellLine:                   "(satır %d)",
+		ImportShellOverwrites:             "[%s seviyesindeki '%s' değiştirilecek]",
+		ImportShellManaged:                "[zaten %s seviyesinde tanımlı]",
+		ImportShellConflict:               "[%s seviyesinde '%s' olarak tanımlı; --overwrite ile değiştirin]",
+		ImportShellSkipGlobal:             "global alias (alias -g) desteklenmiyor",
+		ImportShellSkipSuffix:             "suffix alias (alias -s) desteklenmiyor",
+		ImportShellSkipMultiline:          "birden çok satıra yayılan komutlar desteklenmiyor",
+		ImportShellSkipRedefined:          "%d. satırda yeniden tanımlanıyor",
+		ImportShellSkipShortSecret:        "'%s' sırrının değerini içeriyor; değer yer tutucuyla değiştirilemeyecek kadar kısa, komutu ${secret:...} ile yeniden yazın",
+		ImportShellNothingToDo:            "İçe aktarılacak yeni alias yok.",
+		ImportShellConfirmation:           "%d alias kullanıcı seviyesine eklensin mi? [e/H]: ",
+		ImportShellConfirmationCommentOut: "%d alias kullanıcı seviyesine eklensin ve %[3]s içindeki %[2]d satır yorum satırına çevrilsin mi? [e/H]: ",
+		ImportShellImported:               "%d alias içe aktarıldı.",
+		ImportShellCommentedOut:           "%[2]s içindeki %[1]d satır yorum satırına çevrildi (yedek: %[3]s).",
+		ImportShellMigratedMarker:         "qq'ya taşındı:",
+		// Adopting shell aliases
+		CmdAdoptSummary:       "Kabukta tanımlı alias'ları qq yönetimine al",
+		CmdAdoptDescription:   "Standart girdiden kabuğun alias listesini okur (bash/zsh 'alias', fish 'functions' ve 'abbr --show') ve qq'da olmayanları numaralı olarak listeler; seçtikleriniz kullanıcı seviyesine eklenir. Genellikle 'qq init' ile tanımlanan qq-adopt fonksiyonu üzerinden çalıştırılır. Alias adları, --all veya --yes verilirse soru sorulmaz; --all yalnızca qq'da hiç olmayan alias'ları seçer.",
+		FlagAdoptAllSummary:   "qq'da olmayan tüm alias'ları seç",
+		FlagAdoptShellSummary: "Girdinin hangi kabuktan geldiği (varsayılan: yapılandırılmış kabuk)",
+		AdoptNeedsInput:       "qq adopt alias listesini standart girdiden okur; 'qq-adopt' çalıştırın veya örneğin: alias | qq adopt",
+		AdoptNothingFound:     "Girdide alias tanımı bulunamadı.",
+		AdoptFound:            "Kabukta tanımlı, qq'da olmayan alias'lar:",
+		AdoptManaged:          "%d alias zaten qq tarafından yönetiliyor.",
+		AdoptSelectPrompt:     "Alınacak alias'lar (numara, 2-5 gibi aralık, ad veya 'hepsi'; boş: iptal): ",
+		AdoptInvalidPick:      "geçersiz seçim: %s",
+		AdoptAdopted:          "%d alias qq yönetimine alındı.",
+		AdoptOriginalHint:     "Özgün tanımlar (eklenti veya rc dosyası) qq'dan sonra yüklenirse onları geçersiz kılabilir; gerekirse kaldırın.",
+		// Export formats
+		CmdConfigExportDescription: "Tüm alias'ları tek bir dosyaya yazar. --format json (varsayılan), yaml ve toml tüm alanları korur; bash, zsh, fish ve posix, qq'nun kurulu olmadığı ortamlarda (container, uzak sunucu) source edilebilecek, 'qq init' ile aynı tırnaklamayı kullanan alias satırları üretir ve seviyeleri yorum olarak yazar. --format verilmezse dosya uzantısına bakılır.",
+		FlagExportFormatSummary:    "Dosya biçimi: json, bash, zsh, fish, posix, yaml veya toml",
+		UnknownExportFormat:        "bilinmeyen dışa aktarma biçimi '%s' (desteklenenler: %s)",
+		// YAML and TOML stores
+		StoreLoadWarning:          "%s okunamadı, içindeki alias'lar yüklenmedi: %v",
+		StoreInvalidName:          "%s geçersiz bir alias adı içeriyor; düzeltilene kadar qq bu dosyaya yazmaz: %v",
+		StoreUnreadableRefuseSave: "alias deposu okunamadığı için kaydedilmedi (kaydetmek içindeki alias'ları silerdi); önce dosyayı düzeltin ('qq doctor'): %v",
+		// Apply manifests
+		CmdApplySummary:       "Alias'ları bir manifest dosyasıyla eşitle",
+		CmdApplyDescription:   "Manifest dosyasını (JSON, YAML veya TOML; 'config import' ile aynı biçim) mevcut kullanıcı ve global alias'larla karşılaştırır, bir plan (ekle/değiştir/kaldır) gösterir ve onaydan sonra uygular. Her şey zaten eşleşiyorsa hiçbir şey yazılmaz. Manifestte olmayan alias'lar yalnızca --prune ile ve yalnızca manifestte alias'ı bulunan seviyelerde kaldırılır. --check yalnızca planı gösterir ve fark varsa 2 ile çıkar.",
+		FlagApplyCheckSummary: "Hiçbir şey yazma; fark varsa 2 ile çık",
+		FlagApplyPruneSummary: "Manifestte alias'ı bulunan seviyelerde manifestte olmayan alias'ları kaldır",
+		ApplyNoChanges:        "Değişiklik yok.",
+		ApplyUnmanagedKept:    "Manifestte olmayan %d alias korunuyor (kaldırmak için --prune).",
+		ApplyLevelNotPruned:   "Manifestte bu seviyede alias olmadığından %d alias korunuyor (--prune bu seviyeye dokunmaz).",
+		ApplyUpToDate:         "Alias'lar manifestle eşleşiyor; yapılacak bir şey yok.",
+		ApplyDrift:            "Manifestten %d fark var.",
+		ApplyConfirmation:     "%d değişiklik uygulansın mı? [e/H]: ",
+		ApplyApplied:          "%d değişiklik uygulandı.",
+		ApplyDuplicate:        "'%s' manifestte %s seviyesinde birden fazla kez tanımlı",
+		ApplyUnknownLevel:     "'%s': bilinmeyen seviye '%s' (geçerli olanlar: %s)",
+		// Packs
+		CmdPackSummary:             "Alias paketlerini yönet",
+		CmdPackDescription:         "Paket, meta verisi (name, version, description, author) ve 'aliases' altında alias'ları olan bir manifesttir (pack.json, pack.yaml veya pack.toml). Kurulan paketler global ve kullanıcı alias'ları arasında ayrı bir katman olarak yüklenir: aynı adlı bir kullanıcı alias'ı paketin alias'ını geçersiz kılar. Paketler paket kaynaklarından (qq pack add-source) adıyla da kurulabilir; indirilen paketler güvenilen bir anahtarla imzalanmış olmalıdır.",
+		CmdPackInstallSummary:      "Bir dizinden, manifest dosyasından, .tar.gz arşivinden veya paket kaynağından paket kur",
+		CmdPackListSummary:         "Kurulu paketleri listele",
+		CmdPackRemoveSummary:       "Kurulu bir paketi kaldır",
+		PackAliasesHeader:          "PAKET ALIASLARI:",
+		NoPackAliasesMatch:         "Eşleşen paket alias'ı yok.",
+		PackAliasOverridden:        "kullanıcı alias'ı tarafından geçersiz kılındı",
+		PackAliasOverriddenBy:      "'%s' paketindeki '%s' alias'ı artık bu kullanıcı alias'ı tarafından geçersiz kılınıyor.",
+		UserAliasRemovedPackActive: "'%s' kullanıcı alias'ı kaldırıldı. Şimdi '%s' paketindeki alias aktif: '%s'.",
+		PackManifestNotMapping:     "paket manifesti bir eşleme (name, version, aliases, ...) olmalı",
+		PackInvalidName:            "geçersiz paket adı '%s': harf, rakam, '.', '_' ve '-' kullanılabilir",
+		PackManifestNotFound:       "%s içinde paket manifesti bulunamadı (%s)",
+		PackManifestTooLarge:       "%s paket manifesti çok büyük",
+		PackLoadWarning:            "Paket okunamadı, alias'ları yüklenmedi: %v",
+		PackWriteError:             "paket yazılamadı: %v",
+		PackRemoveError:            "'%s' paketi kaldırılamadı: %v",
+		PackReadError:              "%s paketi okunamadı: %v",
+		PackUpToDate:               "'%s' paketi zaten bu sürümle kurulu.",
+		PackInstallConfirmation:    "'%s' paketi %d alias ile kurulsun mu?",
+		PackReplaceConfirmation:    "Kurulu '%s' paketi (%s) %s ile değiştirilsin mi?",
+		PackInstalled:              "'%s' paketi kuruldu (%d alias).",
+		PackOverriddenByUser:       "= %s: kullanıcı alias'ınız geçerli kalır",
+		PackOverriddenByPack:       "= %s: '%s' paketindeki tanım geçerli kalır",
+		PacksNotInstalled:          "Kurulu paket yok. Kurmak için: qq pack install <yol>",
+		PackAliasCount:             "%d alias",
+		PackOverriddenCount:        "%d tanesi geçersiz kılındı",
+		PackNotInstalled:           "'%s' paketi kurulu değil",
+		PackRemoveConfirmation:     "'%s' paketi ve %d alias'ı kaldırılsın mı?",
+		PackRemoved:                "'%s' paketi kaldırıldı.",
+		PackNoVersion:              "sürümsüz",
+		// Pack sources
+		CmdPackUpdateSummary:        "Paket kaynaklarından kurulan paketleri en yeni sürüme yükselt",
+		CmdPackAddSourceSummary:     "Bir paket kaynağı (statik indeks URL'si) ekle",
+		CmdPackAddSourceDescription: "Paket kaynağı, {\"packs\": [{\"name\", \"version\", \"url\", \"signature\"}]} biçiminde bir JSON indeksidir; URL'ler indekse göre göreli olabilir ve imza varsayılan olarak url + \".minisig\" olur. İndirilen her paket, ayarlardaki pack_trusted_keys anahtarlarından biriyle (ham ed25519 veya minisign) doğrulanır; --key ile bir anahtar da güvenilenlere eklenir.",
+		FlagPackKeySummary:          "Bu kaynağın paketlerini imzalayan açık anahtara güven (base64 ed25519 veya minisign)",
+		PackInvalidPublicKey:        "geçersiz açık anahtar '%s': base64 ed25519 anahtarı veya minisign açık anahtarı bekleniyor",
+		PackNoTrustedKeys:           "güvenilen anahtar yok; 'qq pack add-source <url> --key <anahtar>' ile ekleyin",
+		PackSignatureMalformed:      "imza okunamadı",
+		PackSignatureInvalid:        "imza geçersiz",
+		PackSignatureUnknownKey:     "imza güvenilmeyen %s anahtarıyla atılmış",
+		PackIndexParseError:         "%s paket indeksi okunamadı: %v",
+		PackInvalidRelease:          "indeksteki sürüm geçersiz: %s %s",
+		PackVerificationFailed:      "%s %s doğrulanamadı: %v",
+		PackUnsupportedURL:          "desteklenmeyen URL %s: yalnızca http ve https",
+		PackDownloadFailed:          "%s indirilemedi: %s",
+		PackDownloadTooLarge:        "%s çok büyük",
+		PackNoSources:               "paket kaynağı yok; 'qq pack add-source <url>' ile ekleyin",
+		PackSourceUnavailable:       "%s paket kaynağı okunamadı: %v",
+		PackNotInSources:            "'%s' paketi hiçbir paket kaynağında bulunamadı",
+		PackReleaseMismatch:         "indeks %s %s duyuruyor ama indirilen dosya %s %s içeriyor",
+		PackReleaseUnversioned:      "indirilen %s paketinde sürüm yok; imzalı paket, indeksin duyurduğu sürümü (%s) belirtmeli",
+		PackSignatureVerified:       "%s %s imzası %s anahtarıyla doğrulandı.",
+		PackSourceAdded:             "Paket kaynağı eklendi: %s (%d sürüm).",
+		PackSourceExists:            "%s zaten bir paket kaynağı.",
+		PackKeyTrusted:              "Anahtar güvenilenlere eklendi.",
+		PackTrustHint:               "Henüz güvenilen anahtar yok: paketleri kurmadan önce --key ile imzalayan anahtarı ekleyin.",
+		PackNotFromSource:           "'%s' yerel bir dosyadan kuruldu, güncellenemez.",
+		PacksUpToDate:               "Tüm paketler güncel.",
+		// Secrets
+		WarningCommandSecret:   "komut gizli bir bilgi içeriyor gibi görünüyor (%s); değeri 'qq secret set <ad>' ile saklayıp komutta ${secret:<ad>} kullanın",
+		SecretNotFound:         "'%s' gizli bilgisi gizli bilgi dosyasında, pass'te veya secret-tool'da bulunamadı",
+		SecretAliasSkipped:     "'%s' alias'ı atlandı: %v",
+		InitAliasSkipped:       "qq init '%s' alias'ını tanımlamadı: %v",
+		SecretNotUserLevel:     "yalnızca kullanıcı alias'ları ${secret:…} kullanabilir; %s alias'ı gizli bilgiyi herhangi bir yere gönderebilir",
+		SecretAliasRefused:     "'%s' alias'ı reddedildi: %v",
+		SecretFileTooOpen:      "%s gizli bilgi dosyası yok sayıldı: izinleri %v, yalnızca sahibi erişebilmeli (chmod 600)",
+		SecretInvalidName:      "geçersiz gizli bilgi adı '%s': harf, rakam, '.', '_' ve '-' kullanın",
+		SecretPrompt:           "'%s' gizli bilgisinin değeri: ",
+		SecretEmpty:            "gizli bilgi boş",
+		SecretMultiline:        "gizli bilgi tek satır olmalı",
+		SecretWriteError:       "gizli bilgi dosyası yazılamadı: %v",
+		SecretSaved:            "'%s' gizli bilgisi %s dosyasına kaydedildi",
+		SecretReplaced:         "'%s' gizli bilgisi %s dosyasında değiştirildi",
+		SecretUsageHint:        "Alias'larda ${secret:%s} olarak kullanın; qq init değeri yerine koyar.",
+		SecretNotInFile:        "'%s' gizli bilgisi gizli bilgi dosyasında yok",
+		SecretRemoved:          "'%s' gizli bilgisi silindi",
+		SecretStillUsed:        "'%s' alias'ı hâlâ ${secret:%s} kullanıyor; pass veya secret-tool sağlamadıkça qq init onu atlayacak",
+		SecretsNone:            "Saklanan veya kullanılan gizli bilgi yok.",
+		SecretInFile:           "(gizli bilgi dosyasında)",
+		SecretExternal:         "(dosyada yok: pass veya secret-tool'da aranır)",
+		SecretUnused:           "hiçbir alias kullanmıyor",
+		SecretUsedBy:           "kullanan alias'lar: %s",
+		CmdSecretSummary:       "Alias'larda ${secret:ad} ile kullanılan gizli bilgileri yönet",
+		CmdSecretDescription:   "Token ve parolaları alias komutlarına yazmak yerine ${secret:ad} yer tutucusunu kullanın. qq init yer tutucuyu sırasıyla gizli bilgi dosyasından (~/.config/quickalias/secrets, yalnızca izinleri 0600 iken okunur), 'pass show quickalias/<ad>' ile veya 'secret-tool lookup service quickalias name <ad>' ile doldurur. Alias depoları ve dışa aktarımlar yalnızca yer tutucuyu içerir; bulunamayan gizli bilgiyi kullanan alias'lar bir uyarıyla atlanır. Gizli bilgileri yalnızca kullanıcı alias'ları kullanabilir; paket ve global alias'lardaki yer tutucular reddedilir.",
+		CmdSecretSetSummary:    "Bir gizli bilgiyi kaydet (değer stdin'den veya gizli istemden okunur)",
+		CmdSecretListSummary:   "Gizli bilgileri ve kullanan alias'ları listele (değerler gösterilmez)",
+		CmdSecretRemoveSummary: "Bir gizli bilgiyi dosyadan sil",
+		// Permissions
+		InvalidUmask:          "geçersiz umask '%s': 022 veya 077 gibi sekizlik bir değer olmalı",
+		DoctorPermissionsOK:   "%s içinde %o izninden daha açık dosya yok",
+		DoctorPermissionsOpen: "kullanıcı katmanındaki %d dosya veya dizin fazla açık: %s",
+		DoctorFixUmask:        "config.json içindeki '%s' ayarını 077 gibi sekizlik bir değere düzeltin veya silin",
+		// Global store integrity
+		GlobalStoreWorldWritable:    "%s herkes tarafından yazılabilir",
+		GlobalStoreNotRootOwned:     "%s root'a değil, %d uid'li kullanıcıya ait",
+		GlobalStoreChecksumInvalid:  "%s sağlama dosyası bozuk",
+		GlobalStoreChecksumMismatch: "%s, %s içindeki sağlamayla eşleşmiyor; qq dışında değiştirilmiş",
+		GlobalStoreRefused:          "GLOBAL ALIAS'LAR YÜKLENMEDİ: %v",
+		GlobalStoreRefusedHint:      "%s dosyasını inceleyin. Güveniyorsanız root'a ait ve başkalarınca yazılamaz yapın (sudo chown root:root, sudo chmod 644); qq dışındaki bir değişikliği kabul etmek için %s dosyasını silin.",
+		DoctorGlobalVerified:        "%s root'a ait, herkesçe yazılamaz ve sağlamasıyla eşleşiyor",
+		DoctorGlobalNoChecksum:      "%s root'a ait ve herkesçe yazılamaz; sağlama dosyası yok ('sudo qq set' yazar)",
 	}
 }
 
 func loadEnglishMessages() *messages {
 	return &messages{
-		AddAliasUsage:                "Usage: qq add <alias> \"<command>\"",
-		SetAliasUsage:                "Usage: qq set <alias> \"<command>\" (Add global alias)",
-		RemoveAliasUsage:             "Usage: qq remove <alias>",
-		UnsetAliasUsage:              "Usage: qq unset <alias> (Remove global alias)",
-		SearchAliasUsage:             "Usage: qq search <keyword>",
-		ConfigSubcommandRequired:     "Subcommand required for config command.",
-		UnknownConfigSubcommand:      "Unknown config subcommand: %s",
 		QuickAliasNotSetup:           "QuickAlias doesn't seem to be set up!",
 		RunSetupTip:                  "Please run '%sqq setup%s' to configure QuickAlias.",
 		UnknownCommand:               "Unknown command",
@@ -275,6 +936,7 @@ func loadEnglishMessages() *messages {
 		TotalResultsFound:            "Total %d results found.",
 		QuickAliasStatus:             "QUICKALIAS STATUS",
 		UserAliasesCount:             " %sUser Aliases:%s %d",
+		PackAliasesCount:             " %sPack Aliases:%s %d (%d packs)",
 		GlobalAliasesCount:           " %sGlobal Aliases:%s %d",
 		UserGlobalConflicts:          " %sUser-Global Conflicts:%s %d",
 		ConflictsHint:                "(Conflicting: %s)",
@@ -311,6 +973,7 @@ func loadEnglishMessages() *messages {
 		UsageSystem:                  "System:",
 		UsageConfiguration:           "Configuration:",
 		UsageOther:                   "Other:",
+		UsageGlobalOptions:           "Global Options:",
 		TipsHeader:                   "TIPS:",
 		TipRunSetupFirst:             "Run `qq setup` first.",
 		TipUserOverridesGlobal:       "User-level aliases override global aliases with the same name.",
@@ -328,5 +991,350 @@ func loadEnglishMessages() *messages {
 		MainConfigFileRemovePrompt:   "Sudo privileges required to remove main config file:",
 		ErrorFileSudoRemovalFailed:   "file could not be removed with sudo: %s: %w",
 		ErrorFileRemovalFailed:       "file could not be removed: %s: %w",
+		// Non-interactive mode messages
+		ConfirmationRequired: "This operation requires confirmation but no input can be read. Re-run with --yes to confirm.",
+		// Output format messages
+		FlagNeedsValue:         "flag %s requires a value",
+		InvalidFormatTemplate:  "invalid --format template: %w",
+		FormatNotSupported:     "this command does not support the '%s' output format",
+		ConflictingFormatFlags: "--json cannot be combined with a different --format",
+		// Colour messages
+		InvalidColorMode: "invalid --color value: %s (must be auto, always or never)",
+		// Command summaries
+		CmdAddSummary:                   "Add a user-level alias",
+		CmdSetSummary:                   "Add a global alias (requires sudo)",
+		CmdRemoveSummary:                "Remove a user alias",
+		CmdUnsetSummary:                 "Remove a global alias (requires sudo)",
+		CmdListSummary:                  "List all aliases or filter by keyword",
+		CmdSearchSummary:                "Search alias names and commands",
+		CmdStatusSummary:                "Show status and conflicts",
+		CmdSetupSummary:                 "Set up shell integration",
+		CmdInitSummary:                  "Initialize aliases (used by the shell)",
+		CmdConfigSummary:                "Configuration management",
+		CmdConfigResetSummary:           "Reset configuration",
+		CmdConfigBackupSummary:          "Show available backups",
+		CmdConfigExportSummary:          "Export aliases",
+		CmdConfigImportSummary:          "Import aliases",
+		CmdCompletionSummary:            "Generate a shell completion script (bash, zsh, fish)",
+		CmdVersionSummary:               "Show version",
+		CmdHelpSummary:                  "Show this help",
+		FlagYesSummary:                  "Answer every confirmation with yes",
+		FlagNoInputSummary:              "Never prompt; fail if a confirmation is needed",
+		FlagJSONSummary:                 "Machine-readable JSON output",
+		FlagFormatSummary:               "Format output as TSV or with a Go template",
+		FlagColorSummary:                "Control coloured output (auto, always, never)",
+		ErrorUnsupportedCompletionShell: "completion is not supported for shell: %s",
+		// Command framework messages
+		UsageHeader:            "USAGE:",
+		HelpFlagsPlaceholder:   "flags",
+		HelpAliases:            "Aliases:",
+		HelpSubcommands:        "Subcommands:",
+		HelpFlags:              "Flags:",
+		HelpHint:               "Run 'qq help %s' for details.",
+		TipCommandHelp:         "Use `qq help <command>` or `qq <command> --help` for details on a command.",
+		FlagForceSummary:       "Same as --yes",
+		UnknownFlag:            "unknown flag: %s",
+		UnknownSubcommand:      "unknown subcommand: %s",
+		SubcommandRequired:     "this command requires a subcommand",
+		NotEnoughArguments:     "not enough arguments",
+		TooManyArguments:       "too many arguments: %s",
+		InvalidAliasDefinition: "invalid alias definition: '%s' (expected <name> <command> or <name>=<command>)",
+		CmdAddDescription:      "When the command is given as several arguments, their quoting is preserved:\n  qq add gc git commit -m \"wip fix\"\n  qq add \"ll=ls -la\"\nFlags must come before the alias name; everything after the name is part of the command.",
+		HelpOverviewHint:       "Run 'qq help' to see all commands.",
+		// Validation messages
+		InvalidAliasNameEmpty:           "alias name cannot be empty",
+		InvalidAliasNameWhitespace:      "invalid alias name '%s': it cannot contain whitespace",
+		InvalidAliasNameChar:            "invalid alias name '%s': it cannot contain '%s'",
+		InvalidAliasNameDash:            "invalid alias name '%s': it cannot start with '-'",
+		InvalidAliasNameReserved:        "invalid alias name '%s': it is a reserved word in %s",
+		WarningCommandEmpty:             "the command is empty",
+		WarningCommandUnbalanced:        "the command has an unbalanced %s quote",
+		WarningCommandTrailingBackslash: "the command ends with a backslash",
+		WarningCommandPrefix:            "'%s': %s",
+		ImportValidationFailed:          "the import file contains %d invalid aliases; nothing was changed",
+		// Shadowing messages
+		ShadowingHeader:        "SHADOWING:",
+		ShadowsAlias:           "'%s' (%s) overrides the %s alias of the same name",
+		ShadowsBuiltin:         "'%s' (%s) shadows the shell builtin '%s'",
+		ShadowsCommand:         "'%s' (%s) shadows the command %s",
+		ShadowAllowedMarker:    "(--allow-shadow)",
+		ShadowBlocked:          "'%s' shadows a shell builtin; re-run with --allow-shadow if this is intended",
+		FlagAllowShadowSummary: "Allow shadowing a command or builtin and record it on the alias",
+		// Doctor messages
+		CmdDoctorSummary:          "Check the health of the installation and suggest fixes",
+		DoctorHeader:              "QUICKALIAS DOCTOR",
+		DoctorShellNotConfigured:  "shell type is not configured or not supported",
+		DoctorFixRunSetup:         "run 'qq setup'",
+		DoctorShellOK:             "shell: %s",
+		DoctorShellMissing:        "the %s shell was not found on PATH",
+		DoctorRCOK:                "%s loads QuickAlias",
+		DoctorRCMissing:           "%s has no integration line",
+		DoctorFixAddRCLine:        "run 'qq setup' or add this line to %s: %s",
+		DoctorInitializedMismatch: "the configuration claims setup is done but the shell integration is missing",
+		DoctorNotInitialized:      "the shell integration exists but the configuration says setup was not run",
+		DoctorInitializedOK:       "setup state matches the shell integration",
+		DoctorPathMissing:         "qq is not on PATH, so the shell cannot run 'qq init' at startup",
+		DoctorFixPath:             "install qq to /usr/local/bin or add its directory to PATH before the integration line",
+		DoctorPathOK:              "qq is on PATH: %s",
+		DoctorPathDifferent:       "the qq on PATH (%s) is not the running binary (%s)",
+		DoctorFixPathDifferent:    "remove the old installation or fix the PATH order",
+		DoctorStoreMissing:        "%s does not exist yet",
+		DoctorStoreOK:             "%s: %d aliases",
+		DoctorStoreUnreadable:     "cannot read %s: %v",
+		DoctorStoreInvalid:        "%s cannot be parsed: %v",
+		DoctorFixStoreInvalid:     "fix the file or restore a backup (qq config backup)",
+		DoctorInitSyntaxOK:        "'qq init' output parses with %s",
+		DoctorInitSyntaxFailed:    "'qq init' output does not parse with %s: %s",
+		DoctorFixInitSyntax:       "remove or fix the broken alias with 'qq remove'",
+		DoctorInitSyntaxSkipped:   "shell '%s' not found, syntax check skipped",
+		DoctorShadowingOK:         "no aliases shadow shell builtins",
+		DoctorShadowingFound:      "%d aliases shadow shell builtins: %s",
+		DoctorFixShadowing:        "rename the alias, or re-add it with --allow-shadow if this is intended",
+		DoctorFixLabel:            "Fix:",
+		DoctorSummaryOK:           "No problems found.",
+		DoctorSummaryProblems:     "%d problems found.",
+		DoctorProblemsFound:       "doctor found %d problems",
+		// Metadata messages
+		FlagDescSummary:         "Text describing what the alias is for",
+		FlagTagSummary:          "Add a tag (repeatable or comma-separated)",
+		FlagCategorySummary:     "Category under which 'qq list' groups the alias",
+		FlagListTagSummary:      "Only show aliases with this tag (repeatable)",
+		FlagListCategorySummary: "Only show aliases in this category",
+		// Provenance messages
+		InvalidSortKey:       "invalid sort key '%s' (must be name, created or updated)",
+		FlagSortSummary:      "Sort by name (alphabetical), created or updated (newest first)",
+		CmdShowSummary:       "Show an alias's details and provenance",
+		AliasNotDefined:      "no alias named '%s'",
+		ShowEffectiveMarker:  "(effective)",
+		ShowCommandLabel:     "Command:",
+		ShowDescriptionLabel: "Description:",
+		ShowCategoryLabel:    "Category:",
+		ShowTagsLabel:        "Tags:",
+		ShowCreatedLabel:     "Created:",
+		ShowUpdatedLabel:     "Updated:",
+		ShowCreatedByLabel:   "Created by:",
+		ShowHostLabel:        "Host:",
+		ShowAllowShadowLabel: "Allow shadow:",
+		ShowYes:              "yes",
+		// Resolution messages
+		CmdWhichSummary:      "Explain how an alias resolves and what the shell receives",
+		WhichOverridesHeader: "OVERRIDDEN DEFINITIONS:",
+		WhichInitLineHeader:  "'qq init' OUTPUT (%s):",
+		// Edit messages
+		CmdEditSummary:           "Edit user aliases in $EDITOR",
+		CmdEditDescription:       "Opens the user aliases (or just the named alias) in a temporary file with $VISUAL/$EDITOR. When you save and quit, the file is validated, the changes are shown and, after confirmation, saved with a backup. Naming an alias that does not exist opens an empty template for it.",
+		EditFileHeader:           "Edit the aliases, then save and quit. Lines starting with '#' are ignored.\nFormat:   name = command   (multi-line commands in double quotes: name = \"line 1\\nline 2\")\nOptional indented lines: desc:, tags:, category:, allow-shadow:, disabled:\nConditions (comma-separated): hosts:, os:, shells:, requires:, env:\nDelete an alias by removing its lines.",
+		EditMetadataWithoutAlias: "line %d: indented line does not belong to an alias",
+		EditInvalidLine:          "line %d: expected 'name = command': %s",
+		EditUnknownKey:           "line %d: unknown field '%s' (must be desc, tags, category, allow-shadow, disabled, hosts, os, shells, requires or env)",
+		EditDuplicateAlias:       "line %d: alias '%s' is already defined on line %d",
+		EditOpeningEditor:        "Opening %s...",
+		EditEditorFailed:         "editor failed: %v",
+		EditReopenPrompt:         "Reopen the editor to fix it? [y/N]: ",
+		EditNoChanges:            "No changes.",
+		EditSaveConfirmation:     "Save these changes? [y/N]: ",
+		EditSaved:                "%d changes saved.",
+		DiffMetadataChanged:      "(only description, tags or category changed)",
+		EditShadowBlocked:        "'%s' shadows a shell builtin; add 'allow-shadow: yes' below it if this is intended",
+		// Rename, copy and move messages
+		CmdRenameSummary:  "Rename an alias",
+		CmdCopySummary:    "Copy an alias to a new name",
+		CmdPromoteSummary: "Move a user alias to the global level (requires sudo)",
+		CmdDemoteSummary:  "Move a global alias to the user level (requires sudo)",
+		FlagGlobalSummary: "Work on global instead of user aliases (requires sudo)",
+		SameAliasName:     "the new name is the same as the old one: '%s'",
+		AliasRenamed:      "Alias '%s' renamed to '%s' (%s level).",
+		AliasCopied:       "Alias '%s' copied to '%s' (%s level).",
+		AliasMoved:        "Alias '%s' moved from %s to %s level.",
+		// Enable/disable messages
+		CmdDisableSummary:   "Turn an alias off without deleting it",
+		CmdEnableSummary:    "Turn a disabled alias back on",
+		AliasDisabled:       "Alias '%s' (%s level) disabled.",
+		AliasEnabled:        "Alias '%s' (%s level) enabled.",
+		AliasStateUnchanged: "Alias '%s' (%s level) is already in that state.",
+		DisabledMarker:      "(disabled)",
+		StateEnabled:        "enabled",
+		StateDisabled:       "disabled",
+		ShowStateLabel:      "State:",
+		WhichDisabledHeader: "DISABLED DEFINITIONS:",
+		WhichAllDisabled:    "Every definition is disabled; 'qq init' emits nothing for this alias.",
+		// Condition messages
+		InvalidHostPattern:   "invalid hostname pattern: '%s'",
+		ReasonHost:           "hostname %s does not match %s",
+		ReasonOS:             "OS %s is not %s",
+		ReasonShell:          "shell %s is not %s",
+		ReasonCommand:        "command %s not found",
+		ReasonEnvUnset:       "environment variable %s is not set",
+		ReasonEnvValue:       "environment variable %s is not %s",
+		InactiveMarker:       "(inactive: %s)",
+		ShowConditionsLabel:  "Conditions:",
+		ShowInactiveLabel:    "On this machine:",
+		WhichInactiveHeader:  "DEFINITIONS INACTIVE ON THIS MACHINE:",
+		WhichNoneActive:      "No definition is active; 'qq init' emits nothing for this alias.",
+		FlagIfHostSummary:    "Only active when the hostname matches this glob (repeatable)",
+		FlagIfOSSummary:      "Only active on this OS, e.g. linux, darwin (repeatable)",
+		FlagIfShellSummary:   "Only active in this shell (repeatable)",
+		FlagIfCommandSummary: "Only active when this command is on PATH (repeatable)",
+		FlagIfEnvSummary:     "Only active when VAR is set, or VAR=value matches (repeatable)",
+		// Import from shell files
+		CmdImportShellSummary:             "Import aliases from a shell configuration file",
+		CmdImportShellDescription:         "Finds the alias definitions in the given file (default: your shell's rc file), including several definitions on one line, zsh alias -g/-s and fish alias/abbr forms. It shows a preview, flags names that clash with existing aliases and, after confirmation, adds them at user level. --overwrite also replaces clashing aliases; --comment-out comments out the migrated lines in the source file (after saving a .qq-backup copy of it).",
+		FlagOverwriteSummary:              "Also import definitions that clash with existing aliases",
+		FlagCommentOutSummary:             "Comment out the migrated lines in the source file",
+		ImportShellNothingFound:           "No alias definitions found in %s.",
+		ImportShellFound:                  "Found %d alias definitions in %s:",
+		ImportShellLine:                   "(line %d)",
+		ImportShellOverwrites:             "[replaces %s alias '%s']",
+		ImportShellManaged:                "[already defined at %s level]",
+		ImportShellConflict:               "[defined at %s level as '%s'; use --overwrite to replace]",
+		ImportShellSkipGlobal:             "global aliases (alias -g) are not supported",
+		ImportShellSkipSuffix:             "suffix aliases (alias -s) are not supported",
+		ImportShellSkipMultiline:          "commands spanning several lines are not supported",
+		ImportShellSkipRedefined:          "redefined on line %d",
+		ImportShellSkipShortSecret:        "holds the value of secret '%s', which is too short to replace with its placeholder; rewrite the command with ${secret:...}",
+		ImportShellNothingToDo:            "There are no new aliases to import.",
+		ImportShellConfirmation:           "Add %d aliases at user level? [y/N]: ",
+		ImportShellConfirmationCommentOut: "Add %d aliases at user level and comment out %d lines in %s? [y/N]: ",
+		ImportShellImported:               "Imported %d aliases.",
+		ImportShellCommentedOut:           "Commented out %d lines in %s (backup: %s).",
+		ImportShellMigratedMarker:         "moved to qq:",
+		// Adopting shell aliases
+		CmdAdoptSummary:       "Bring aliases defined in the shell under qq management",
+		CmdAdoptDescription:   "Reads the shell's alias listing from stdin (bash/zsh 'alias', fish 'functions' and 'abbr --show') and lists the aliases qq does not have yet; the ones you pick are added at user level. Usually run through the qq-adopt function defined by 'qq init'. Nothing is asked when alias names, --all or --yes are given; --all only picks aliases qq does not have at all.",
+		FlagAdoptAllSummary:   "Pick every alias qq does not have yet",
+		FlagAdoptShellSummary: "Shell the listing comes from (default: the configured shell)",
+		AdoptNeedsInput:       "qq adopt reads the alias listing from stdin; run 'qq-adopt' or, for example: alias | qq adopt",
+		AdoptNothingFound:     "No alias definitions found in the input.",
+		AdoptFound:            "Aliases defined in the shell but not in qq:",
+		AdoptManaged:          "%d aliases are already managed by qq.",
+		AdoptSelectPrompt:     "Aliases to adopt (numbers, ranges like 2-5, names or 'all'; empty: cancel): ",
+		AdoptInvalidPick:      "invalid pick: %s",
+		AdoptAdopted:          "Adopted %d aliases.",
+		AdoptOriginalHint:     "The original definitions (plugin or rc file) may override qq if they load after it; remove them if needed.",
+		// Export formats
+		CmdConfigExportDescription: "Writes all aliases to one file. --format json (default), yaml and toml keep every field; bash, zsh, fish and posix produce alias lines with the same quoting as 'qq init' that can be sourced where qq is not installed (containers, remote servers), with levels as comments. Without --format the file extension decides.",
+		FlagExportFormatSummary:    "File format: json, bash, zsh, fish, posix, yaml or toml",
+		UnknownExportFormat:        "unknown export format '%s' (supported: %s)",
+		// YAML and TOML stores
+		StoreLoadWarning:          "Could not read %s, its aliases were not loaded: %v",
+		StoreInvalidName:          "%s holds an invalid alias name; qq will not write to it until it is fixed: %v",
+		StoreUnreadableRefuseSave: "the alias store could not be read, so it was not saved (saving would lose its aliases); fix the file first ('qq doctor'): %v",
+		// Apply manifests
+		CmdApplySummary:       "Make aliases match a manifest file",
+		CmdApplyDescription:   "Compares a manifest file (JSON, YAML or TOML; the same format as 'config import') with the current user and global aliases, shows a plan (add/change/remove) and applies it after confirmation. Nothing is written when everything already matches. Aliases not in the manifest are only removed with --prune, and only at the levels the manifest has aliases for. --check only shows the plan and exits with 2 when there are differences.",
+		FlagApplyCheckSummary: "Write nothing; exit with 2 when there are differences",
+		FlagApplyPruneSummary: "Remove aliases not in the manifest at the levels it has aliases for",
+		ApplyNoChanges:        "No changes.",
+		ApplyUnmanagedKept:    "%d aliases not in the manifest are kept (--prune removes them).",
+		ApplyLevelNotPruned:   "%d aliases are kept: the manifest has no aliases at this level, so --prune leaves it alone.",
+		ApplyUpToDate:         "Aliases match the manifest; nothing to do.",
+		ApplyDrift:            "%d differences from the manifest.",
+		ApplyConfirmation:     "Apply %d changes? [y/N]: ",
+		ApplyApplied:          "Applied %d changes.",
+		ApplyDuplicate:        "'%s' is defined more than once at %s level in the manifest",
+		ApplyUnknownLevel:     "'%s': unknown level '%s' (expected %s)",
+		// Packs
+		CmdPackSummary:             "Manage alias packs",
+		CmdPackDescription:         "A pack is a manifest (pack.json, pack.yaml or pack.toml) with metadata (name, version, description, author) and aliases under 'aliases'. Installed packs are loaded as their own layer between global and user aliases: a user alias with the same name overrides the pack's alias. Packs can also be installed by name from pack sources (qq pack add-source); downloaded packs must be signed with a trusted key.",
+		CmdPackInstallSummary:      "Install a pack from a directory, manifest file, .tar.gz archive or pack source",
+		CmdPackListSummary:         "List installed packs",
+		CmdPackRemoveSummary:       "Remove an installed pack",
+		PackAliasesHeader:          "PACK ALIASES:",
+		NoPackAliasesMatch:         "No matching pack aliases.",
+		PackAliasOverridden:        "overridden by a user alias",
+		PackAliasOverriddenBy:      "This user alias now overrides '%[2]s' from pack '%[1]s'.",
+		UserAliasRemovedPackActive: "User alias '%s' removed. The alias from pack '%s' -> '%s' is now active.",
+		PackManifestNotMapping:     "a pack manifest must be a mapping (name, version, aliases, ...)",
+		PackInvalidName:            "invalid pack name '%s': use letters, digits, '.', '_' and '-'",
+		PackManifestNotFound:       "no pack manifest found in %s (%s)",
+		PackManifestTooLarge:       "pack manifest %s is too large",
+		PackLoadWarning:            "Could not read a pack, its aliases were not loaded: %v",
+		PackWriteError:             "could not write the pack: %v",
+		PackRemoveError:            "could not remove pack '%s': %v",
+		PackReadError:              "could not read pack %s: %v",
+		PackUpToDate:               "Pack '%s' is already installed at this version.",
+		PackInstallConfirmation:    "Install pack '%s' with %d aliases?",
+		PackReplaceConfirmation:    "Replace installed pack '%s' (%s) with %s?",
+		PackInstalled:              "Pack '%s' installed (%d aliases).",
+		PackOverriddenByUser:       "= %s: your user alias stays in effect",
+		PackOverriddenByPack:       "= %s: the definition from pack '%s' stays in effect",
+		PacksNotInstalled:          "No packs installed. Install one with: qq pack install <path>",
+		PackAliasCount:             "%d aliases",
+		PackOverriddenCount:        "%d overridden",
+		PackNotInstalled:           "pack '%s' is not installed",
+		PackRemoveConfirmation:     "Remove pack '%s' and its %d aliases?",
+		PackRemoved:                "Pack '%s' removed.",
+		PackNoVersion:              "no version",
+		// Pack sources
+		CmdPackUpdateSummary:        "Upgrade packs installed from pack sources to their newest version",
+		CmdPackAddSourceSummary:     "Add a pack source (the URL of a static index)",
+		CmdPackAddSourceDescription: "A pack source is a JSON index {\"packs\": [{\"name\", \"version\", \"url\", \"signature\"}]}; URLs may be relative to the index and the signature defaults to url + \".minisig\". Every downloaded pack is verified against one of the keys in the pack_trusted_keys setting (raw ed25519 or minisign); --key adds a key to the trusted ones.",
+		FlagPackKeySummary:          "Trust the public key that signs this source's packs (base64 ed25519 or minisign)",
+		PackInvalidPublicKey:        "invalid public key '%s': expected a base64 ed25519 key or a minisign public key",
+		PackNoTrustedKeys:           "no trusted keys; add one with 'qq pack add-source <url> --key <key>'",
+		PackSignatureMalformed:      "the signature cannot be read",
+		PackSignatureInvalid:        "the signature is invalid",
+		PackSignatureUnknownKey:     "the signature was made with key %s, which is not trusted",
+		PackIndexParseError:         "could not parse pack index %s: %v",
+		PackInvalidRelease:          "invalid release in the index: %s %s",
+		PackVerificationFailed:      "could not verify %s %s: %v",
+		PackUnsupportedURL:          "unsupported URL %s: only http and https",
+		PackDownloadFailed:          "could not download %s: %s",
+		PackDownloadTooLarge:        "%s is too large",
+		PackNoSources:               "no pack sources; add one with 'qq pack add-source <url>'",
+		PackSourceUnavailable:       "could not read pack source %s: %v",
+		PackNotInSources:            "pack '%s' was not found in any pack source",
+		PackReleaseMismatch:         "the index announces %s %s but the downloaded file holds %s %s",
+		PackReleaseUnversioned:      "the downloaded %s pack has no version; a signed pack must name the version the index announces (%s)",
+		PackSignatureVerified:       "Signature of %s %s verified with key %s.",
+		PackSourceAdded:             "Pack source added: %s (%d releases).",
+		PackSourceExists:            "%s is already a pack source.",
+		PackKeyTrusted:              "Key added to the trusted keys.",
+		PackTrustHint:               "No keys are trusted yet: add the signing key with --key before installing packs.",
+		PackNotFromSource:           "'%s' was installed from a local file and cannot be updated.",
+		PacksUpToDate:               "All packs are up to date.",
+		// Secrets
+		WarningCommandSecret:   "the command looks like it contains a secret (%s); store the value with 'qq secret set <name>' and write ${secret:<name>} in the command instead",
+		SecretNotFound:         "secret '%s' was not found in the secret file, pass or secret-tool",
+		SecretAliasSkipped:     "alias '%s' skipped: %v",
+		InitAliasSkipped:       "qq init did not define alias '%s': %v",
+		SecretNotUserLevel:     "only user aliases can use ${secret:…}; a %s alias could send the secret anywhere",
+		SecretAliasRefused:     "alias '%s' refused: %v",
+		SecretFileTooOpen:      "secret file %s ignored: its permissions are %v but only its owner may access it (chmod 600)",
+		SecretInvalidName:      "invalid secret name '%s': use letters, digits, '.', '_' and '-'",
+		SecretPrompt:           "Value of secret '%s': ",
+		SecretEmpty:            "the secret is empty",
+		SecretMultiline:        "a secret must be a single line",
+		SecretWriteError:       "could not write the secret file: %v",
+		SecretSaved:            "Secret '%s' saved to %s",
+		SecretReplaced:         "Secret '%s' replaced in %s",
+		SecretUsageHint:        "Use it in aliases as ${secret:%s}; qq init fills in the value.",
+		SecretNotInFile:        "secret '%s' is not in the secret file",
+		SecretRemoved:          "Secret '%s' removed",
+		SecretStillUsed:        "alias '%s' still uses ${secret:%s}; qq init will skip it unless pass or secret-tool provides the secret",
+		SecretsNone:            "No secrets are stored or used.",
+		SecretInFile:           "(in the secret file)",
+		SecretExternal:         "(not in the file: looked up in pass or secret-tool)",
+		SecretUnused:           "not used by any alias",
+		SecretUsedBy:           "used by: %s",
+		CmdSecretSummary:       "Manage secrets used as ${secret:name} in aliases",
+		CmdSecretDescription:   "Instead of writing tokens and passwords into alias commands, use the placeholder ${secret:name}. qq init fills it in from the secret file (~/.config/quickalias/secrets, only read while its permissions are 0600), then 'pass show quickalias/<name>', then 'secret-tool lookup service quickalias name <name>'. Alias stores and exports only hold the placeholder; aliases using a secret that cannot be found are skipped with a warning. Only user aliases can use secrets; placeholders in pack and global aliases are refused.",
+		CmdSecretSetSummary:    "Store a secret (the value is read from stdin or a hidden prompt)",
+		CmdSecretListSummary:   "List secrets and the aliases using them (values are not shown)",
+		CmdSecretRemoveSummary: "Remove a secret from the secret file",
+		// Permissions
+		InvalidUmask:          "invalid umask '%s': it must be an octal value such as 022 or 077",
+		DoctorPermissionsOK:   "no file in %s is more open than %o",
+		DoctorPermissionsOpen: "%d files or directories in the user layer are too permissive: %s",
+		DoctorFixUmask:        "fix the '%s' setting in config.json to an octal value such as 077, or remove it",
+		// Global store integrity
+		GlobalStoreWorldWritable:    "%s is writable by every user",
+		GlobalStoreNotRootOwned:     "%s is owned by uid %d, not by root",
+		GlobalStoreChecksumInvalid:  "checksum file %s is malformed",
+		GlobalStoreChecksumMismatch: "%s does not match its checksum in %s; it was changed outside qq",
+		GlobalStoreRefused:          "GLOBAL ALIASES NOT LOADED: %v",
+		GlobalStoreRefusedHint:      "Review %s. If you trust it, make it owned by root and not writable by others (sudo chown root:root, sudo chmod 644); to accept a change made outside qq, delete %s.",
+		DoctorGlobalVerified:        "%s is owned by root, not world-writable and matches its checksum",
+		DoctorGlobalNoChecksum:      "%s is owned by root and not world-writable; it has no checksum file ('sudo qq set' writes one)",
 	}
 }
@@ -0,0 +1,111 @@
+package ui
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+var (
+	// ErrCancelled is returned when the user declines a confirmation prompt.
+	ErrCancelled = errors.New("operation cancelled")
+	// ErrConfirmationRequired is returned when a confirmation is needed but no input can be read,
+	// either because --no-input was given or stdin is not a terminal.
+	ErrConfirmationRequired = errors.New("confirmation required")
+)
+
+// promptSettings controls how confirmation prompts behave.
+type promptSettings struct {
+	AssumeYes bool // --yes / --force: answer every confirmation with yes.
+	NoInput   bool // --no-input: never read from stdin.
+}
+
+var Prompt = &promptSettings{} // Global prompt behaviour, configured from the command-line flags in main.
+
+// CanPrompt reports whether interactive input may be read from stdin.
+func CanPrompt() bool {
+	return !Prompt.NoInput && IsTerminal(os.Stdin)
+}
+
+// Confirm asks a yes/no question and returns nil only if the answer is affirmative.
+// With --yes it returns nil without asking. When input cannot be read it returns
+// ErrConfirmationRequired instead of blocking; a negative answer returns ErrCancelled.
+func Confirm(prompt string) error {
+	if Prompt.AssumeYes {
+		return nil
+	}
+	if !CanPrompt() {
+		return ErrConfirmationRequired
+	}
+
+	fmt.Print(prompt)
+	var response string
+	fmt.Scanln(&response) // Read user input for confirmation.
+	if IsYes(response) {
+		return nil
+	}
+	return ErrCancelled
+}
+
+// Ask prints prompt and reads one line of input. When stdin is a pipe, as in `alias | qq adopt`,
+// the answer is read from the controlling terminal instead. It returns ErrConfirmationRequired
+// when no terminal is available or --no-input was given.
+func Ask(prompt string) (string, error) {
+	if Prompt.NoInput {
+		return "", ErrConfirmationRequired
+	}
+	in := os.Stdin
+	if !IsTerminal(in) {
+		tty, err := os.Open("/dev/tty")
+		if err != nil {
+			return "", ErrConfirmationRequired
+		}
+		defer tty.Close()
+		if !IsTerminal(tty) {
+			return "", ErrConfirmationRequired
+		}
+		in = tty
+	}
+
+	fmt.Print(prompt)
+	answer, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && answer == "" {
+		return "", ErrCancelled
+	}
+	return strings.TrimSpace(answer), nil
+}
+
+// AskSecret prints prompt and reads one line from the terminal without echoing it, for
+// values such as tokens that must not appear on screen. Like Ask, it returns
+// ErrConfirmationRequired when there is no terminal and ErrCancelled on end of input.
+func AskSecret(prompt string) (string, error) {
+	if Prompt.NoInput {
+		return "", ErrConfirmationRequired
+	}
+	tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
+	if err != nil {
+		return "", ErrConfirmationRequired
+	}
+	defer tty.Close()
+	if !IsTerminal(tty) {
+		return "", ErrConfirmationRequired
+	}
+
+	fmt.Fprint(tty, prompt)
+	answer, err := readHidden(tty)
+	if err != nil && answer == "" {
+		return "", ErrCancelled
+	}
+	return strings.TrimRight(answer, "\r\n"), nil
+}
+
+// IsYes reports whether an answer means yes, in Turkish or English.
+func IsYes(answer string) bool {
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "e", "evet", "y", "yes", "true":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,113 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"text/template"
+)
+
+// Output format kinds selectable with --json and --format.
+const (
+	FormatText     = "text"
+	FormatJSON     = "json"
+	FormatTSV      = "tsv"
+	FormatTemplate = "template"
+)
+
+// TSVRecord is implemented by values that can be written as tab-separated rows.
+type TSVRecord interface {
+	TSVRows() [][]string
+}
+
+// OutputFormat describes how a command renders its result data.
+// The zero value is the human-readable text format.
+type OutputFormat struct {
+	Kind     string
+	Template *template.Template
+}
+
+// ParseOutputFormat converts a --format value into an OutputFormat.
+// "text", "json" and "tsv" are recognized; anything else is parsed as a Go template.
+func ParseOutputFormat(spec string) (OutputFormat, error) {
+	switch spec {
+	case "", FormatText:
+		return OutputFormat{Kind: FormatText}, nil
+	case FormatJSON:
+		return OutputFormat{Kind: FormatJSON}, nil
+	case FormatTSV:
+		return OutputFormat{Kind: FormatTSV}, nil
+	}
+
+	tmpl, err := template.New("format").Parse(spec)
+	if err != nil {
+		return OutputFormat{}, fmt.Errorf(Msg.InvalidFormatTemplate, err)
+	}
+	return OutputFormat{Kind: FormatTemplate, Template: tmpl}, nil
+}
+
+// IsText reports whether the command should print its usual human-readable output.
+func (f OutputFormat) IsText() bool {
+	return f.Kind == "" || f.Kind == FormatText
+}
+
+// Render writes v to w in the machine-readable format f.
+// Templates are executed once per element when v is a slice, otherwise once for v.
+func (f OutputFormat) Render(w io.Writer, v interface{}) error {
+	switch f.Kind {
+	case FormatJSON:
+		data, err := json.MarshalIndent(v, "", "  ") // Use 2 spaces for indentation
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "%s\n", data)
+		return err
+	case FormatTSV:
+		record, ok := v.(TSVRecord)
+		if !ok {
+			return fmt.Errorf(Msg.FormatNotSupported, f.Kind)
+		}
+		for _, row := range record.TSVRows() {
+			for i, field := range row {
+				row[i] = escapeTSV(field)
+			}
+			if _, err := fmt.Fprintln(w, strings.Join(row, "\t")); err != nil {
+				return err
+			}
+		}
+		return nil
+	case FormatTemplate:
+		rv := reflect.ValueOf(v)
+		if rv.Kind() == reflect.Slice {
+			for i := 0; i < rv.Len(); i++ {
+				if err := executeLine(w, f.Template, rv.Index(i).Interface()); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		return executeLine(w, f.Template, v)
+	}
+	return fmt.Errorf(Msg.FormatNotSupported, f.Kind)
+}
+
+// executeLine runs tmpl for a single value and terminates the output with a newline.
+func executeLine(w io.Writer, tmpl *template.Template, v interface{}) error {
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, v); err != nil {
+		return err
+	}
+	out := sb.String()
+	if !strings.HasSuffix(out, "\n") {
+		out += "\n"
+	}
+	_, err := io.WriteString(w, out)
+	return err
+}
+
+// escapeTSV keeps a field on a single line and inside its column.
+func escapeTSV(field string) string {
+	return strings.NewReplacer("\\", "\\\\", "\t", "\\t", "\n", "\\n", "\r", "\\r").Replace(field)
+}
@@ -0,0 +1,9 @@
+package ui
+
+import "os"
+
+// IsTerminal reports whether the given file is attached to a terminal.
+// Pipes, regular files and /dev/null all report false.
+func IsTerminal(f *os.File) bool {
+	return isTerminal(f.Fd())
+}
@@ -0,0 +1,34 @@
+//go:build darwin || freebsd || netbsd || openbsd
+
+package ui
+
+import (
+	"bufio"
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// isTerminal asks the kernel for the terminal attributes of fd; only a tty has them.
+func isTerminal(fd uintptr) bool {
+	var termios syscall.Termios
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, syscall.TIOCGETA, uintptr(unsafe.Pointer(&termios)))
+	return errno == 0
+}
+
+// readHidden reads a line from the terminal f with echo turned off, restoring the
+// terminal afterwards.
+func readHidden(f *os.File) (string, error) {
+	var saved syscall.Termios
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), syscall.TIOCGETA, uintptr(unsafe.Pointer(&saved))); errno != 0 {
+		return "", errno
+	}
+	hidden := saved
+	hidden.Lflag &^= syscall.ECHO
+	hidden.Lflag |= syscall.ICANON | syscall.ECHONL
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), syscall.TIOCSETA, uintptr(unsafe.Pointer(&hidden))); errno != 0 {
+		return "", errno
+	}
+	defer syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), syscall.TIOCSETA, uintptr(unsafe.Pointer(&saved)))
+	return bufio.NewReader(f).ReadString('\n')
+}
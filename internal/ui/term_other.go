@@ -0,0 +1,18 @@
+//go:build !linux && !darwin && !freebsd && !netbsd && !openbsd
+
+package ui
+
+import (
+	"errors"
+	"os"
+)
+
+// isTerminal is a conservative fallback for platforms without a tty ioctl: never assume a terminal.
+func isTerminal(fd uintptr) bool {
+	return false
+}
+
+// readHidden cannot turn off echo on this platform, so it refuses to read secrets.
+func readHidden(f *os.File) (string, error) {
+	return "", errors.New("hidden input is not supported on this platform")
+}
@@ -1,16 +1,21 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"os/user"
 	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
 	"quickalias/internal/alias"
+	"quickalias/internal/cli"
 	"quickalias/internal/config"
+	"quickalias/internal/doctor"
 	"quickalias/internal/shell"
 	"quickalias/internal/ui" // ui paketini import et
 )
@@ -21,14 +26,25 @@ const (
 	GLOBAL_CONFIG_DIR = "/etc/quickalias"
 )
 
+// Exit codes returned by qq. Scripts can rely on these to tell a failure from a declined prompt.
+const (
+	EXIT_OK                    = 0
+	EXIT_ERROR                 = 1
+	EXIT_DRIFT                 = 2 // `qq apply --check` found differences from the manifest.
+	EXIT_CANCELLED             = 3 // The user answered "no" to a confirmation prompt.
+	EXIT_CONFIRMATION_REQUIRED = 4 // A confirmation was needed but --no-input was set or stdin is not a terminal.
+)
+
 // QuickAlias is the main struct that encapsulates the application's state and methods.
 type QuickAlias struct {
 	UserConfigPath   string
 	GlobalConfigPath string
 	UserAliases      []alias.Alias // alias.Alias struct'ını kullan
 	GlobalAliases    []alias.Alias // alias.Alias struct'ını kullan
+	PackAliases      []alias.Alias // Aliases of the installed packs, between global and user aliases.
 	Config           config.Config // config.Config struct'ını kullan
 	PersistManager   *alias.PersistManager
+	Output           ui.OutputFormat // Output format selected with --json / --format.
 }
 
 // GetShellType implements the shell.QuickAliasConfig interface.
@@ -53,134 +69,152 @@ func (qa *QuickAlias) SaveConfig() error {
 }
 
 func main() {
-	// Check if any arguments are provided. If not, show usage.
-	if len(os.Args) < 2 {
-		ui.ShowUsage() // ui paketinden ShowUsage'ı çağır
-		return
+	// Initialize QuickAlias instance.
+	qa, err := NewQuickAlias()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s❌ %s%s\n", ui.Color.Red, fmt.Errorf(ui.Msg.ErrorInitializingQA, err), ui.Color.Reset)
+		os.Exit(EXIT_ERROR)
 	}
 
-	command := os.Args[1]
-	args := os.Args[2:]
-
-	// Handle `set` and `unset` commands with automatic sudo retry.
-	if (command == "set" || command == "unset") && os.Geteuid() != 0 {
-		fmt.Printf("%s%s%s\n", ui.ColorRed+ui.ColorBold, ui.Msg.AccessDeniedGlobalAlias, ui.ColorReset)
-		fmt.Printf("%s💡 %s%s\n", ui.ColorCyan, ui.Msg.AttemptingAsAdmin, ui.ColorReset)
-
-		exe, err := os.Executable()
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "%s❌ %s%s\n", ui.ColorRed, fmt.Errorf(ui.Msg.ErrorInitializingQA, err), ui.ColorReset)
-			os.Exit(1)
+	commands := qa.commandTable()
+	inv, err := cli.Parse(commands, os.Args[1:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s❌ Hata: %v%s\n", ui.Color.Red, err, ui.Color.Reset)
+		var usageErr *cli.UsageError
+		if errors.As(err, &usageErr) {
+			cli.PrintUsageError(usageErr)
 		}
+		os.Exit(EXIT_ERROR)
+	}
 
-		sudoArgs := []string{exe}
-		sudoArgs = append(sudoArgs, os.Args[1:]...)
+	// Apply global flags (--yes, --force, --no-input, --json, --format, --color) before running anything.
+	opts := inv.Context.GlobalOptions()
+	if err := ui.SetColorMode(opts.Color); err != nil {
+		fmt.Fprintf(os.Stderr, "%s❌ Hata: %v%s\n", ui.Color.Red, err, ui.Color.Reset)
+		os.Exit(EXIT_ERROR)
+	}
+	ui.Prompt.AssumeYes = opts.Yes
+	ui.Prompt.NoInput = opts.NoInput
 
-		cmd := exec.Command("sudo", sudoArgs...)
-		cmd.Stdin = os.Stdin
-		cmd.Stdout = os.Stdout
-		cmd.Stderr = os.Stderr
+	if inv.Command != nil && inv.Command.OwnsFlag("format") {
+		opts.Format = "" // e.g. `config export --format` selects the file format, not the output format.
+	}
+	qa.Output, err = opts.OutputFormat()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s❌ Hata: %v%s\n", ui.Color.Red, err, ui.Color.Reset)
+		os.Exit(EXIT_ERROR)
+	}
+
+	// Without a command, or with --help, show the usage.
+	if inv.Command == nil || inv.Help {
+		cli.PrintHelp(commands, inv.Path)
+		return
+	}
 
-		err = cmd.Run()
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "%s❌ Hata: %v%s\n", ui.ColorRed, err, ui.ColorReset)
-			os.Exit(1)
+	// A global store that failed verification was not loaded; qq init reports it in its script.
+	if err := qa.PersistManager.GlobalStoreError(); err != nil && inv.Command.Name != "init" {
+		for _, line := range qa.globalStoreWarning(err) {
+			fmt.Fprintf(os.Stderr, "%s%s%s\n", ui.Color.Red+ui.Color.Bold, line, ui.Color.Reset)
 		}
-		os.Exit(0)
 	}
 
-	// Initialize QuickAlias instance.
-	qa, err := NewQuickAlias()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "%s❌ %s%s\n", ui.ColorRed, fmt.Errorf(ui.Msg.ErrorInitializingQA, err), ui.ColorReset)
-		os.Exit(1)
+	// Handle privileged commands (`set`, `unset`, ...) with automatic sudo retry.
+	if inv.Command.NeedsPrivileges(inv.Context) && os.Geteuid() != 0 {
+		os.Exit(retryWithSudo())
 	}
 
-	skipInitCheck := []string{"setup", "init", "version", "help", "--help", "-h"}
-	needsInit := true
-	for _, cmd := range skipInitCheck {
-		if command == cmd {
-			needsInit = false
-			break
-		}
+	if !inv.Command.SkipInit && !qa.Config.Initialized {
+		fmt.Printf("%s⚠️  %s%s\n", ui.Color.Yellow+ui.Color.Bold, ui.Msg.QuickAliasNotSetup, ui.Color.Reset)
+		fmt.Printf("%s💡 %s%s\n", ui.Color.Cyan, fmt.Sprintf(ui.Msg.RunSetupTip, ui.Color.Bold, ui.Color.Reset), ui.Color.Reset)
+		os.Exit(EXIT_ERROR)
 	}
 
-	if needsInit && !qa.Config.Initialized {
-		fmt.Printf("%s⚠️  %s%s\n", ui.ColorYellow+ui.ColorBold, ui.Msg.QuickAliasNotSetup, ui.ColorReset)
-		fmt.Printf("%s💡 %s%s\n", ui.ColorCyan, fmt.Sprintf(ui.Msg.RunSetupTip, ui.ColorBold, ui.ColorReset), ui.ColorReset)
-		os.Exit(1)
+	os.Exit(exitCode(inv.Command.Run(inv.Context)))
+}
+
+// retryWithSudo re-executes the current command line through sudo and returns its exit code.
+func retryWithSudo() int {
+	fmt.Printf("%s%s%s\n", ui.Color.Red+ui.Color.Bold, ui.Msg.AccessDeniedGlobalAlias, ui.Color.Reset)
+	fmt.Printf("%s💡 %s%s\n", ui.Color.Cyan, ui.Msg.AttemptingAsAdmin, ui.Color.Reset)
+
+	exe, err := os.Executable()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s❌ %s%s\n", ui.Color.Red, fmt.Errorf(ui.Msg.ErrorInitializingQA, err), ui.Color.Reset)
+		return EXIT_ERROR
 	}
 
-	// Handle different commands based on user input.
-	switch command {
-	case "add":
-		if len(args) < 2 {
-			fmt.Fprintf(os.Stderr, "%s%s%s\n", ui.ColorRed, ui.Msg.AddAliasUsage, ui.ColorReset)
-			os.Exit(1)
-		}
-		err = qa.AddAlias(args[0], strings.Join(args[1:], " "), "user")
-	case "set":
-		if len(args) < 2 {
-			fmt.Fprintf(os.Stderr, "%s%s%s\n", ui.ColorRed, ui.Msg.SetAliasUsage, ui.ColorReset)
-			os.Exit(1)
-		}
-		err = qa.AddAlias(args[0], strings.Join(args[1:], " "), "global")
-	case "remove":
-		if len(args) < 1 {
-			fmt.Fprintf(os.Stderr, "%s%s%s\n", ui.ColorRed, ui.Msg.RemoveAliasUsage, ui.ColorReset)
-			os.Exit(1)
-		}
-		err = qa.RemoveAlias(args[0], "user")
-	case "unset":
-		if len(args) < 1 {
-			fmt.Fprintf(os.Stderr, "%s%s%s\n", ui.ColorRed, ui.Msg.UnsetAliasUsage, ui.ColorReset)
-			os.Exit(1)
-		}
-		err = qa.RemoveAlias(args[0], "global")
-	case "list":
-		keyword := ""
-		if len(args) > 0 {
-			keyword = args[0]
-		}
-		err = qa.ListAliases(keyword)
-	case "search":
-		if len(args) < 1 {
-			fmt.Fprintf(os.Stderr, "%s%s%s\n", ui.ColorRed, ui.Msg.SearchAliasUsage, ui.ColorReset)
-			os.Exit(1)
-		}
-		err = qa.SearchAliases(args[0])
-	case "control", "status":
-		err = qa.ShowStatus()
-	case "setup":
-		err = qa.Setup()
-	case "init":
-		err = qa.Init()
-	case "config":
-		if len(args) < 1 {
-			fmt.Fprintf(os.Stderr, "%s%s%s\n", ui.ColorRed, ui.Msg.ConfigSubcommandRequired, ui.ColorReset)
-			os.Exit(1)
+	// sudo must not wait for a password when input is disabled.
+	sudoArgs := []string{}
+	if !ui.CanPrompt() {
+		sudoArgs = append(sudoArgs, "-n")
+	}
+	sudoArgs = append(sudoArgs, exe)
+	sudoArgs = append(sudoArgs, os.Args[1:]...)
+
+	cmd := exec.Command("sudo", sudoArgs...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		// Propagate the exit code of the elevated qq so cancellations stay distinguishable.
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return exitErr.ExitCode()
 		}
-		err = qa.HandleConfig(args)
-	case "version":
-		fmt.Printf("%sQuickAlias (qq) versiyon %s%s%s\n", ui.ColorGreen+ui.ColorBold, VERSION, ui.ColorReset, ui.ColorReset)
-	case "help", "--help", "-h":
-		ui.ShowUsage()
+		fmt.Fprintf(os.Stderr, "%s❌ Hata: %v%s\n", ui.Color.Red, err, ui.Color.Reset)
+		return EXIT_ERROR
+	}
+	return EXIT_OK
+}
+
+var (
+	// errDrift is returned by `qq apply --check` when the aliases differ from the manifest.
+	errDrift = errors.New("aliases differ from the manifest")
+	// errPrivilegesRequired is returned by commands that find out while running that
+	// they must write global aliases; the command is retried through sudo.
+	errPrivilegesRequired = errors.New("root privileges required")
+)
+
+// exitCode reports err to the user and maps it to the process exit code.
+func exitCode(err error) int {
+	switch {
+	case err == nil:
+		return EXIT_OK
+	case errors.Is(err, ui.ErrCancelled):
+		// The cancellation message has already been printed by the command.
+		return EXIT_CANCELLED
+	case errors.Is(err, errDrift):
+		// The differences have already been printed by the command.
+		return EXIT_DRIFT
+	case errors.Is(err, errPrivilegesRequired):
+		return retryWithSudo()
+	case errors.Is(err, ui.ErrConfirmationRequired):
+		fmt.Fprintf(os.Stderr, "%s❌ %s%s\n", ui.Color.Red, ui.Msg.ConfirmationRequired, ui.Color.Reset)
+		return EXIT_CONFIRMATION_REQUIRED
 	default:
-		fmt.Fprintf(os.Stderr, "%s%s: %s%s\n", ui.ColorRed, ui.Msg.UnknownCommand, command, ui.ColorReset)
-		ui.ShowUsage()
-		os.Exit(1)
+		fmt.Fprintf(os.Stderr, "%s❌ Hata: %v%s\n", ui.Color.Red, err, ui.Color.Reset)
+		return EXIT_ERROR
 	}
+}
 
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "%s❌ Hata: %v%s\n", ui.ColorRed, err, ui.ColorReset)
-		os.Exit(1)
+// invokingUser returns the user qq acts for. When qq runs as root through sudo this is
+// the user who ran sudo, so privileged commands still use that user's aliases; sudo is
+// then true.
+func invokingUser() (u *user.User, sudo bool, err error) {
+	if name := os.Getenv("SUDO_USER"); os.Geteuid() == 0 && name != "" && name != "root" {
+		if u, err := user.Lookup(name); err == nil {
+			return u, true, nil
+		}
 	}
+	u, err = user.Current()
+	return u, false, err
 }
 
 // NewQuickAlias creates and initializes a new QuickAlias instance.
 // It sets up configuration paths and loads existing aliases and configurations.
 func NewQuickAlias() (*QuickAlias, error) {
-	currentUser, err := user.Current()
+	currentUser, sudo, err := invokingUser()
 	if err != nil {
 		return nil, fmt.Errorf(ui.Msg.ErrorGettingCurrentUser, err)
 	}
@@ -193,6 +227,7 @@ func NewQuickAlias() (*QuickAlias, error) {
 		GlobalConfigPath: globalConfigPath,
 		UserAliases:      []alias.Alias{},
 		GlobalAliases:    []alias.Alias{},
+		PackAliases:      []alias.Alias{},
 		Config: config.Config{ // config paketinden Config struct'ı
 			Version:     VERSION,
 			ShellType:   "",
@@ -201,22 +236,34 @@ func NewQuickAlias() (*QuickAlias, error) {
 		},
 	}
 
+	// The config comes first: its umask setting decides the modes of the user layer.
+	config.LoadConfig(qa.UserConfigPath, &qa.Config) // config paketinden çağır
+
+	// Initialize PersistManager
+	qa.PersistManager = alias.NewPersistManager(qa.UserConfigPath, qa.GlobalConfigPath, &qa.UserAliases, &qa.GlobalAliases, &qa.PackAliases)
+	qa.PersistManager.SetUmask(qa.Config.Settings[alias.SettingUmask])
+
+	// Under sudo, files written to the user layer must stay owned by the invoking user.
+	if sudo {
+		uid, _ := strconv.Atoi(currentUser.Uid)
+		gid, _ := strconv.Atoi(currentUser.Gid)
+		qa.PersistManager.SetUserOwner(uid, gid)
+	}
+
 	// Create user config directory if it doesn't exist.
-	if err := os.MkdirAll(userConfigPath, 0755); err != nil {
+	if err := qa.PersistManager.MkdirUser(userConfigPath); err != nil {
 		return nil, fmt.Errorf(ui.Msg.ErrorCreatingUserConfigDir, err)
 	}
 
 	// Create backup directory within user config if it doesn't exist.
-	if err := os.MkdirAll(filepath.Join(userConfigPath, alias.BACKUP_DIR), 0755); err != nil { // alias.BACKUP_DIR kullan
+	if err := qa.PersistManager.MkdirUser(filepath.Join(userConfigPath, alias.BACKUP_DIR)); err != nil { // alias.BACKUP_DIR kullan
 		return nil, fmt.Errorf(ui.Msg.ErrorCreatingBackupDir, err)
 	}
+	qa.PersistManager.ChownUser(userConfigPath)
+	qa.PersistManager.ChownUser(filepath.Join(userConfigPath, alias.BACKUP_DIR))
 
-	// Initialize PersistManager
-	qa.PersistManager = alias.NewPersistManager(qa.UserConfigPath, qa.GlobalConfigPath, &qa.UserAliases, &qa.GlobalAliases)
-
-	// Load existing aliases and config.
-	qa.PersistManager.LoadAliases()                  // PersistManager üzerinden çağır
-	config.LoadConfig(qa.UserConfigPath, &qa.Config) // config paketinden çağır
+	// Load existing aliases.
+	qa.PersistManager.LoadAliases() // PersistManager üzerinden çağır
 
 	return qa, nil
 }
@@ -227,30 +274,34 @@ func (qa *QuickAlias) SaveAliases(level string) error {
 	return qa.PersistManager.SaveAliases(level, ui.Msg.ErrorProcessingAliasData, ui.Msg.ErrorWritingAliasFile, ui.Msg.ErrorCreatingUserConfigDir)
 }
 
-// AddAlias adds a new alias or updates an existing one at the specified level.
-// It performs permission checks for global aliases and handles conflicts.
-func (qa *QuickAlias) AddAlias(name, command, level string) error {
+// AddAlias adds a new alias or updates an existing one at the level set on newAlias.
+// It validates the name, checks for shadowing and handles conflicts.
+func (qa *QuickAlias) AddAlias(newAlias alias.Alias) error {
+	name, level := newAlias.Name, newAlias.Level
+	if err := qa.checkNewAlias(newAlias); err != nil {
+		return err
+	}
+
 	// Check for existing alias and prompt for overwrite.
 	existingAlias, existingLevel := alias.GetAlias(name, qa.UserAliases, qa.GlobalAliases) // alias paketinden GetAlias
 	if existingAlias != nil && existingLevel != "" {
-		fmt.Printf("%s⚠️  %s%s", ui.ColorYellow, fmt.Sprintf(ui.Msg.WarningAliasExists, name, existingLevel), ui.ColorReset)
-		var response string
-		fmt.Scanln(&response) // Read user input for confirmation.
-		if strings.ToLower(response) != "e" && strings.ToLower(response) != "evet" && strings.ToLower(response) != "y" {
-			fmt.Printf("%s❌ %s%s\n", ui.ColorRed, ui.Msg.OperationCancelled, ui.ColorReset)
-			return nil
+		if err := confirmOverwrite(name, existingLevel); err != nil {
+			return err
 		}
 	}
 
 	// Create a backup before making changes.
 	qa.PersistManager.CreateBackup(level, ui.Msg.ErrorProcessingBackupData, ui.Msg.ErrorWritingBackupFile)
 
-	// Create the new alias struct.
-	newAlias := alias.Alias{ // alias.Alias struct'ı
-		Name:    name,
-		Command: command,
-		Created: time.Now().Format("2006-01-02 15:04:05"),
-		Level:   level,
+	// Overwriting an alias at the same level keeps its original creation details.
+	levelAliases := qa.UserAliases
+	if level == "global" {
+		levelAliases = qa.GlobalAliases
+	}
+	if previous, ok := alias.FindAlias(name, levelAliases); ok {
+		alias.Stamp(&newAlias, &previous, time.Now())
+	} else {
+		alias.Stamp(&newAlias, nil, time.Now())
 	}
 
 	// Add or update the alias in the appropriate slice using alias package functions.
@@ -267,9 +318,206 @@ func (qa *QuickAlias) AddAlias(name, command, level string) error {
 		return err
 	}
 
-	fmt.Printf("%s✅ %s%s\n", ui.ColorGreen, fmt.Sprintf(ui.Msg.AliasAddedSuccess, name, level), ui.ColorReset)
+	fmt.Printf("%s✅ %s%s\n", ui.Color.Green, fmt.Sprintf(ui.Msg.AliasAddedSuccess, name, level), ui.Color.Reset)
+	if packAlias, ok := alias.FindAlias(name, qa.PackAliases); ok && level == "user" {
+		fmt.Printf("%s💡 %s%s\n", ui.Color.Cyan, fmt.Sprintf(ui.Msg.PackAliasOverriddenBy, packAlias.PackName(), name), ui.Color.Reset)
+	}
+
+	fmt.Printf("\n%s💡 %s %s%s%s\n", ui.Color.Cyan, ui.Msg.RestartTerminalHint, ui.Color.Bold, ui.Msg.RestartTerminalCmdHint, ui.Color.Reset)
+	return nil
+}
+
+// checkNewAlias validates an alias about to be saved at a.Level: it rejects names the shell
+// cannot use (global aliases must work in every shell), prints command warnings and shadowing,
+// and refuses to shadow a builtin without --allow-shadow.
+func (qa *QuickAlias) checkNewAlias(a alias.Alias) error {
+	shellType := qa.Config.ShellType
+	if a.Level == "global" {
+		shellType = ""
+	}
+	if err := alias.ValidateName(a.Name, shellType); err != nil {
+		return err
+	}
+	if err := a.Conditions.Validate(); err != nil {
+		return err
+	}
+	if err := alias.CheckSecretLevel(a); err != nil {
+		return err
+	}
+	alias.PrintCommandWarnings(a.Name, alias.CheckCommand(a.Command))
+
+	findings := alias.ShadowedBy(a, shellType)
+	for _, f := range findings {
+		alias.PrintFinding(f)
+	}
+	if alias.HighestSeverity(findings) == alias.SeverityError {
+		return fmt.Errorf(ui.Msg.ShadowBlocked, a.Name)
+	}
+	return nil
+}
+
+// confirmOverwrite asks before replacing the alias name that exists at level.
+func confirmOverwrite(name, level string) error {
+	prompt := fmt.Sprintf("%s⚠️  %s%s", ui.Color.Yellow, fmt.Sprintf(ui.Msg.WarningAliasExists, name, level), ui.Color.Reset)
+	if err := ui.Confirm(prompt); err != nil {
+		if errors.Is(err, ui.ErrCancelled) {
+			fmt.Printf("%s❌ %s%s\n", ui.Color.Red, ui.Msg.OperationCancelled, ui.Color.Reset)
+		}
+		return err
+	}
+	return nil
+}
+
+// levelAliases returns the aliases of the given level ("user" or "global").
+func (qa *QuickAlias) levelAliases(level string) *[]alias.Alias {
+	if level == "global" {
+		return &qa.GlobalAliases
+	}
+	return &qa.UserAliases
+}
+
+// RenameAlias renames an alias within a level, keeping its command and metadata.
+func (qa *QuickAlias) RenameAlias(oldName, newName, level string) error {
+	aliases := qa.levelAliases(level)
+	source, ok := alias.FindAlias(oldName, *aliases)
+	if !ok {
+		return fmt.Errorf(ui.Msg.AliasNotFound, oldName, level)
+	}
+	if oldName == newName {
+		return fmt.Errorf(ui.Msg.SameAliasName, newName)
+	}
+
+	renamed := source
+	renamed.Name = newName
+	if err := qa.checkNewAlias(renamed); err != nil {
+		return err
+	}
+	if _, exists := alias.FindAlias(newName, *aliases); exists {
+		if err := confirmOverwrite(newName, level); err != nil {
+			return err
+		}
+	}
+
+	qa.PersistManager.CreateBackup(level, ui.Msg.ErrorProcessingBackupData, ui.Msg.ErrorWritingBackupFile)
+	renamed.Updated = time.Now().Format(time.RFC3339)
+	*aliases = alias.RemoveAlias(newName, *aliases)
+	for i := range *aliases {
+		if (*aliases)[i].Name == oldName {
+			(*aliases)[i] = renamed
+		}
+	}
+	if err := qa.SaveAliases(level); err != nil {
+		return err
+	}
+
+	fmt.Printf("%s✅ %s%s\n", ui.Color.Green, fmt.Sprintf(ui.Msg.AliasRenamed, oldName, newName, level), ui.Color.Reset)
+	fmt.Printf("\n%s💡 %s %s%s%s\n", ui.Color.Cyan, ui.Msg.RestartTerminalHint, ui.Color.Bold, ui.Msg.RestartTerminalCmdHint, ui.Color.Reset)
+	return nil
+}
+
+// CopyAlias copies an alias to a new name within a level. The copy keeps the
+// command, description, tags and category but gets its own creation details.
+func (qa *QuickAlias) CopyAlias(name, newName, level string) error {
+	aliases := qa.levelAliases(level)
+	source, ok := alias.FindAlias(name, *aliases)
+	if !ok {
+		return fmt.Errorf(ui.Msg.AliasNotFound, name, level)
+	}
+	if name == newName {
+		return fmt.Errorf(ui.Msg.SameAliasName, newName)
+	}
+
+	copied := source
+	copied.Name = newName
+	copied.Tags = append([]string(nil), source.Tags...)
+	if err := qa.checkNewAlias(copied); err != nil {
+		return err
+	}
+	if _, exists := alias.FindAlias(newName, *aliases); exists {
+		if err := confirmOverwrite(newName, level); err != nil {
+			return err
+		}
+	}
+
+	qa.PersistManager.CreateBackup(level, ui.Msg.ErrorProcessingBackupData, ui.Msg.ErrorWritingBackupFile)
+	alias.Stamp(&copied, nil, time.Now())
+	*aliases = append(alias.RemoveAlias(newName, *aliases), copied)
+	if err := qa.SaveAliases(level); err != nil {
+		return err
+	}
+
+	fmt.Printf("%s✅ %s%s\n", ui.Color.Green, fmt.Sprintf(ui.Msg.AliasCopied, name, newName, level), ui.Color.Reset)
+	fmt.Printf("\n%s💡 %s %s%s%s\n", ui.Color.Cyan, ui.Msg.RestartTerminalHint, ui.Color.Bold, ui.Msg.RestartTerminalCmdHint, ui.Color.Reset)
+	return nil
+}
+
+// MoveAlias moves an alias between the user and global levels (`qq promote` / `qq demote`),
+// keeping its metadata. Both levels are backed up before either is changed.
+func (qa *QuickAlias) MoveAlias(name, from, to string) error {
+	source, ok := alias.FindAlias(name, *qa.levelAliases(from))
+	if !ok {
+		return fmt.Errorf(ui.Msg.AliasNotFound, name, from)
+	}
+
+	moved := source
+	moved.Level = to
+	if err := qa.checkNewAlias(moved); err != nil {
+		return err
+	}
+	if _, exists := alias.FindAlias(name, *qa.levelAliases(to)); exists {
+		if err := confirmOverwrite(name, to); err != nil {
+			return err
+		}
+	}
+
+	qa.PersistManager.CreateBackup(from, ui.Msg.ErrorProcessingBackupData, ui.Msg.ErrorWritingBackupFile)
+	qa.PersistManager.CreateBackup(to, ui.Msg.ErrorProcessingBackupData, ui.Msg.ErrorWritingBackupFile)
+
+	// Write the target first: if saving the source then fails, the alias exists twice rather than not at all.
+	moved.Updated = time.Now().Format(time.RFC3339)
+	target := qa.levelAliases(to)
+	*target = append(alias.RemoveAlias(name, *target), moved)
+	if err := qa.SaveAliases(to); err != nil {
+		return err
+	}
+	origin := qa.levelAliases(from)
+	*origin = alias.RemoveAlias(name, *origin)
+	if err := qa.SaveAliases(from); err != nil {
+		return err
+	}
+
+	fmt.Printf("%s✅ %s%s\n", ui.Color.Green, fmt.Sprintf(ui.Msg.AliasMoved, name, from, to), ui.Color.Reset)
+	fmt.Printf("\n%s💡 %s %s%s%s\n", ui.Color.Cyan, ui.Msg.RestartTerminalHint, ui.Color.Bold, ui.Msg.RestartTerminalCmdHint, ui.Color.Reset)
+	return nil
+}
+
+// SetAliasEnabled enables or disables an alias without deleting it.
+// Disabled aliases are kept in the store but skipped by `qq init`.
+func (qa *QuickAlias) SetAliasEnabled(name, level string, enabled bool) error {
+	aliases := qa.levelAliases(level)
+	index := slices.IndexFunc(*aliases, func(a alias.Alias) bool { return a.Name == name })
+	if index < 0 {
+		return fmt.Errorf(ui.Msg.AliasNotFound, name, level)
+	}
+
+	message := ui.Msg.AliasEnabled
+	if !enabled {
+		message = ui.Msg.AliasDisabled
+	}
+	if (*aliases)[index].Disabled == !enabled {
+		fmt.Printf("%s💡 %s%s\n", ui.Color.Cyan, fmt.Sprintf(ui.Msg.AliasStateUnchanged, name, level), ui.Color.Reset)
+		return nil
+	}
+
+	qa.PersistManager.CreateBackup(level, ui.Msg.ErrorProcessingBackupData, ui.Msg.ErrorWritingBackupFile)
+	(*aliases)[index].Disabled = !enabled
+	(*aliases)[index].Updated = time.Now().Format(time.RFC3339)
+	if err := qa.SaveAliases(level); err != nil {
+		return err
+	}
 
-	fmt.Printf("\n%s💡 %s %s%s%s\n", ui.ColorCyan, ui.Msg.RestartTerminalHint, ui.ColorBold, ui.Msg.RestartTerminalCmdHint, ui.ColorReset)
+	fmt.Printf("%s✅ %s%s\n", ui.Color.Green, fmt.Sprintf(message, name, level), ui.Color.Reset)
+	fmt.Printf("\n%s💡 %s %s%s%s\n", ui.Color.Cyan, ui.Msg.RestartTerminalHint, ui.Color.Bold, ui.Msg.RestartTerminalCmdHint, ui.Color.Reset)
 	return nil
 }
 
@@ -289,7 +537,7 @@ func (qa *QuickAlias) RemoveAlias(name, level string) error {
 	}
 
 	if !found {
-		fmt.Printf("%s❌ %s%s\n", ui.ColorRed, fmt.Sprintf(ui.Msg.AliasNotFound, name, level), ui.ColorReset)
+		fmt.Printf("%s❌ %s%s\n", ui.Color.Red, fmt.Sprintf(ui.Msg.AliasNotFound, name, level), ui.Color.Reset)
 		return nil
 	}
 
@@ -298,7 +546,7 @@ func (qa *QuickAlias) RemoveAlias(name, level string) error {
 		return err
 	}
 
-	// Check if a global alias with the same name should now become active if a user alias was removed.
+	// Check if a pack or global alias with the same name should now become active if a user alias was removed.
 	var alternativeAlias *alias.Alias
 	for _, a := range qa.GlobalAliases {
 		if a.Name == name {
@@ -306,15 +554,17 @@ func (qa *QuickAlias) RemoveAlias(name, level string) error {
 			break
 		}
 	}
-
-	if alternativeAlias != nil {
+	if a, ok := alias.FindAlias(name, qa.PackAliases); ok && level == "user" {
+		fmt.Printf("%s✅ %s%s\n",
+			ui.Color.Green, fmt.Sprintf(ui.Msg.UserAliasRemovedPackActive, name, a.PackName(), a.Command), ui.Color.Reset)
+	} else if alternativeAlias != nil {
 		fmt.Printf("%s✅ %s%s\n",
-			ui.ColorGreen, fmt.Sprintf(ui.Msg.UserAliasRemovedGlobalActive, name, name, alternativeAlias.Command), ui.ColorReset)
+			ui.Color.Green, fmt.Sprintf(ui.Msg.UserAliasRemovedGlobalActive, name, name, alternativeAlias.Command), ui.Color.Reset)
 	} else {
-		fmt.Printf("%s✅ %s%s\n", ui.ColorGreen, fmt.Sprintf(ui.Msg.AliasRemovedSuccess, name, level), ui.ColorReset)
+		fmt.Printf("%s✅ %s%s\n", ui.Color.Green, fmt.Sprintf(ui.Msg.AliasRemovedSuccess, name, level), ui.Color.Reset)
 	}
 
-	fmt.Printf("\n%s💡 %s %s%s%s\n", ui.ColorCyan, ui.Msg.RestartTerminalHint, ui.ColorBold, ui.Msg.RestartTerminalCmdHint, ui.ColorReset)
+	fmt.Printf("\n%s💡 %s %s%s%s\n", ui.Color.Cyan, ui.Msg.RestartTerminalHint, ui.Color.Bold, ui.Msg.RestartTerminalCmdHint, ui.Color.Reset)
 	return nil
 }
 
@@ -324,28 +574,118 @@ func (qa *QuickAlias) AliasExists(name string) bool {
 	return level != ""
 }
 
-// ListAliases prints all user and global aliases, optionally filtered by a keyword.
-func (qa *QuickAlias) ListAliases(keyword string) error {
-	alias.ListAliases(qa.UserAliases, qa.GlobalAliases, keyword, ui.Msg.GlobalAliasesHeader, ui.Msg.UserAliasesHeader, ui.Msg.NoGlobalAliases, ui.Msg.NoUserAliases, ui.Msg.TotalAliasesFound, ui.ColorPurple+ui.ColorBold, ui.ColorBlue+ui.ColorBold, ui.ColorGreen, ui.ColorReset, ui.ColorYellow, ui.ColorCyan)
+// ListAliases prints all user, pack and global aliases, optionally filtered by a keyword.
+// sortKey is empty to keep the stored order, or one of alias.SortKeys.
+func (qa *QuickAlias) ListAliases(filter alias.Filter, sortKey string) error {
+	userAliases, packAliases, globalAliases := qa.UserAliases, qa.PackAliases, qa.GlobalAliases
+	all := append(append(append([]alias.Alias{}, globalAliases...), packAliases...), userAliases...)
+	if sortKey != "" {
+		var err error
+		if userAliases, err = alias.SortAliases(userAliases, sortKey); err != nil {
+			return err
+		}
+		packAliases, _ = alias.SortPackAliases(packAliases, sortKey)
+		globalAliases, _ = alias.SortAliases(globalAliases, sortKey)
+		all, _ = alias.SortAliases(all, sortKey)
+	}
+
+	if !qa.Output.IsText() {
+		return qa.Output.Render(os.Stdout, alias.AliasList(alias.FilterAliases(all, filter)))
+	}
+	alias.ListAliases(userAliases, packAliases, globalAliases, filter, alias.CurrentMachine(qa.Config.ShellType))
 	return nil
 }
 
 // SearchAliases searches for aliases containing the given keyword in their name or command.
 func (qa *QuickAlias) SearchAliases(keyword string) error {
-	alias.SearchAliases(qa.UserAliases, qa.GlobalAliases, keyword, ui.Msg.SearchResults, ui.Msg.GlobalAliasesHeader, ui.Msg.UserAliasesHeader, ui.Msg.NoResultsFound, ui.Msg.TotalResultsFound, ui.ColorCyan+ui.ColorBold, ui.ColorRed, ui.ColorGreen, ui.ColorReset, ui.ColorPurple, ui.ColorBlue, ui.ColorCyan)
+	if !qa.Output.IsText() {
+		return qa.ListAliases(alias.Filter{Keyword: keyword}, "")
+	}
+	alias.SearchAliases(qa.UserAliases, qa.PackAliases, qa.GlobalAliases, keyword, alias.CurrentMachine(qa.Config.ShellType))
+	return nil
+}
+
+// ShowAlias prints the details and provenance of every definition of an alias,
+// starting with the one that takes effect.
+func (qa *QuickAlias) ShowAlias(name string) error {
+	var definitions alias.AliasList
+	if a, ok := alias.FindAlias(name, qa.UserAliases); ok {
+		definitions = append(definitions, a)
+	}
+	if a, ok := alias.FindAlias(name, qa.PackAliases); ok {
+		definitions = append(definitions, a)
+	}
+	if a, ok := alias.FindAlias(name, qa.GlobalAliases); ok {
+		definitions = append(definitions, a)
+	}
+	if len(definitions) == 0 {
+		return fmt.Errorf(ui.Msg.AliasNotDefined, name)
+	}
+
+	if !qa.Output.IsText() {
+		return qa.Output.Render(os.Stdout, definitions)
+	}
+	for i, a := range definitions {
+		if i > 0 {
+			fmt.Println()
+		}
+		alias.ShowAlias(a, i == 0 && len(definitions) > 1, alias.CurrentMachine(qa.Config.ShellType))
+	}
+	return nil
+}
+
+// WhichAlias explains how an alias name resolves for the configured shell.
+func (qa *QuickAlias) WhichAlias(name string) error {
+	resolution, ok := alias.Resolve(name, qa.UserAliases, qa.PackAliases, qa.GlobalAliases, alias.CurrentMachine(qa.Config.ShellType))
+	if !ok {
+		return fmt.Errorf(ui.Msg.AliasNotDefined, name)
+	}
+	if !qa.Output.IsText() {
+		return qa.Output.Render(os.Stdout, resolution)
+	}
+	alias.ShowResolution(resolution)
 	return nil
 }
 
 // ShowStatus displays the current status of QuickAlias, including alias counts and conflicts.
 func (qa *QuickAlias) ShowStatus() error {
-	conflicts := alias.FindConflicts(qa.UserAliases, qa.GlobalAliases) // alias.FindConflicts kullan
-	alias.ShowStatus(len(qa.UserAliases), len(qa.GlobalAliases), conflicts, qa.Config.Initialized, ui.Msg.QuickAliasStatus, ui.Msg.UserAliasesCount, ui.Msg.GlobalAliasesCount, ui.Msg.UserGlobalConflicts, ui.Msg.ConflictsHint, ui.Msg.ShellIntegrationStatus, ui.Msg.StatusActive, ui.Msg.StatusNotActive, ui.Msg.ConflictPrecedenceHint, ui.ColorCyan+ui.ColorBold, ui.ColorBlue, ui.ColorPurple, ui.ColorGreen, ui.ColorYellow, ui.ColorReset, ui.ColorWhite)
+	status := alias.NewStatus(qa.UserAliases, qa.PackAliases, qa.GlobalAliases, len(qa.PersistManager.Packs), qa.Config.ShellType, qa.Config.Initialized)
+	if !qa.Output.IsText() {
+		return qa.Output.Render(os.Stdout, status)
+	}
+	return alias.ShowStatus(status)
+}
+
+// Doctor checks the whole installation and reports problems with suggested fixes.
+func (qa *QuickAlias) Doctor() error {
+	report := doctor.Run(doctor.Environment{
+		ShellType:        qa.Config.ShellType,
+		Initialized:      qa.Config.Initialized,
+		UserConfigPath:   qa.UserConfigPath,
+		GlobalConfigPath: qa.GlobalConfigPath,
+		UserAliases:      qa.UserAliases,
+		GlobalAliases:    qa.GlobalAliases,
+		InitScript:       qa.initScript(),
+		Umask:            qa.Config.Settings[alias.SettingUmask],
+	})
+	if !qa.Output.IsText() {
+		if err := qa.Output.Render(os.Stdout, report); err != nil {
+			return err
+		}
+	} else {
+		doctor.PrintReport(report)
+	}
+	for _, c := range report.Checks {
+		if c.Status == doctor.StatusFail {
+			return fmt.Errorf(ui.Msg.DoctorProblemsFound, report.Problems)
+		}
+	}
 	return nil
 }
 
 // Setup initializes QuickAlias by detecting the shell and adding shell integration.
 func (qa *QuickAlias) Setup() error {
-	fmt.Printf("%s%s%s\n", ui.ColorCyan+ui.ColorBold, ui.Msg.SetupStarting, ui.ColorReset)
+	fmt.Printf("%s%s%s\n", ui.Color.Cyan+ui.Color.Bold, ui.Msg.SetupStarting, ui.Color.Reset)
 
 	detectedShell := shell.DetectShell() // shell paketinden DetectShell
 	if detectedShell == "" {
@@ -353,71 +693,61 @@ func (qa *QuickAlias) Setup() error {
 	}
 
 	qa.Config.ShellType = detectedShell
-	fmt.Printf("%s%s %s%s%s\n", ui.ColorGreen, ui.Msg.ShellDetected, ui.ColorBold, detectedShell, ui.ColorReset)
+	fmt.Printf("%s%s %s%s%s\n", ui.Color.Green, ui.Msg.ShellDetected, ui.Color.Bold, detectedShell, ui.Color.Reset)
 
 	// Add the necessary integration line to the shell's configuration file using the new package.
-	if err := shell.AddShellIntegration(qa, ui.ColorGreen, ui.ColorYellow, ui.ColorReset); err != nil { // shell.AddShellIntegration kullan
+	if err := shell.AddShellIntegration(qa); err != nil { // shell.AddShellIntegration kullan
 		return err
 	}
 
 	qa.Config.Initialized = true // Mark as initialized.
 	qa.SaveConfig()              // Save the updated configuration.
 
-	fmt.Printf("%s✅ Kurulum tamamlandı!%s\n", ui.ColorGreen+ui.ColorBold, ui.ColorReset)
+	fmt.Printf("%s✅ Kurulum tamamlandı!%s\n", ui.Color.Green+ui.Color.Bold, ui.Color.Reset)
 
 	// Automatically run init after setup to load aliases.
-	fmt.Printf("%s%s%s\n", ui.ColorCyan, ui.Msg.AliasesLoading, ui.ColorReset)
+	fmt.Printf("%s%s%s\n", ui.Color.Cyan, ui.Msg.AliasesLoading, ui.Color.Reset)
 	if err := qa.Init(); err != nil {
-		fmt.Printf("%s⚠️  %s%s\n", ui.ColorYellow, fmt.Errorf(ui.Msg.InitFailedWarning, err), ui.ColorReset)
+		fmt.Printf("%s⚠️  %s%s\n", ui.Color.Yellow, fmt.Errorf(ui.Msg.InitFailedWarning, err), ui.Color.Reset)
 	}
 
-	fmt.Printf("\n%s💡 %s %s%s%s\n", ui.ColorCyan, ui.Msg.RestartTerminalHint, ui.ColorBold, ui.Msg.RestartTerminalCmdHint, ui.ColorReset)
+	fmt.Printf("\n%s💡 %s %s%s%s\n", ui.Color.Cyan, ui.Msg.RestartTerminalHint, ui.Color.Bold, ui.Msg.RestartTerminalCmdHint, ui.Color.Reset)
 	return nil
 }
 
+// globalStoreWarning returns the lines of the warning shown when the global store failed
+// verification and its aliases were not loaded.
+func (qa *QuickAlias) globalStoreWarning(err error) []string {
+	path := alias.StorePath(qa.GlobalConfigPath)
+	return []string{
+		"⚠️  " + fmt.Sprintf(ui.Msg.GlobalStoreRefused, err),
+		"   " + fmt.Sprintf(ui.Msg.GlobalStoreRefusedHint, path, alias.ChecksumPath(path)),
+	}
+}
+
 // Init outputs alias commands suitable for evaluation by the shell.
 // This function is typically called by the `eval "$(qq init)"` line in shell config.
 func (qa *QuickAlias) Init() error {
-	// Output global aliases first.
-	for _, a := range qa.GlobalAliases {
-		fmt.Printf("alias %s='%s'\n", a.Name, a.Command)
-	}
-
-	// Then output user aliases, which will override global aliases if names conflict.
-	for _, a := range qa.UserAliases {
-		fmt.Printf("alias %s='%s'\n", a.Name, a.Command)
-	}
-
+	fmt.Print(qa.initScript())
 	return nil
 }
 
-// HandleConfig manages various configuration-related sub-commands.
-func (qa *QuickAlias) HandleConfig(args []string) error {
-	if len(args) == 0 {
-		return fmt.Errorf(ui.Msg.ConfigSubcommandRequired)
-	}
-
-	switch args[0] {
-	case "reset":
-		return config.ResetConfig(&qa.Config, VERSION) // Sadece 2 parametre geçiliyor
-	case "backup":
-		return qa.PersistManager.ShowBackups(ui.ColorCyan+ui.ColorBold, ui.ColorGreen, ui.ColorReset, ui.ColorYellow, ui.Msg.AvailableBackups, ui.Msg.BackupsNotFound) // PersistManager.ShowBackups kullan
-	case "export":
-		exportPath := filepath.Join(os.Getenv("HOME"), "quickalias_export.json") // Default export path.
-		if len(args) > 1 {
-			exportPath = args[1] // User-specified export path.
-			if !filepath.IsAbs(exportPath) {
-				currentDir, _ := os.Getwd()
-				exportPath = filepath.Join(currentDir, exportPath)
-			}
-		}
-		return qa.PersistManager.ExportConfig(exportPath, ui.Msg.ExportDataProcessingError, ui.Msg.ExportFileWriteError, ui.Msg.ExportConfigSuccess, ui.ColorGreen, ui.ColorBold, ui.ColorReset) // PersistManager.ExportConfig kullan
-	case "import":
-		if len(args) < 2 {
-			return fmt.Errorf(ui.Msg.ImportFileReadError, "path not provided")
+// initScript returns the script printed by `qq init`; `qq doctor` checks its syntax.
+func (qa *QuickAlias) initScript() string {
+	var sb strings.Builder
+	// Global aliases are output first, then pack aliases; user aliases override both if names conflict.
+	user, pack, global := qa.resolveSecrets()
+	script, errs := alias.InitScript(user, pack, global, alias.CurrentMachine(qa.Config.ShellType))
+	for _, err := range errs {
+		fmt.Fprintf(os.Stderr, "%s⚠️  qq: %v%s\n", ui.Color.Yellow, err, ui.Color.Reset)
+	}
+	sb.WriteString(script)
+	// The warning is part of the script, so it is shown even when the shell discards stderr.
+	if err := qa.PersistManager.GlobalStoreError(); err != nil {
+		for _, line := range qa.globalStoreWarning(err) {
+			fmt.Fprintf(&sb, "echo %s >&2\n", shell.QuoteFor(qa.Config.ShellType, line))
 		}
-		return qa.PersistManager.ImportConfig(args[1], ui.ColorYellow, ui.ColorRed, ui.ColorGreen, ui.ColorReset, ui.Msg.ImportConfirmation, ui.Msg.OperationCancelled, ui.Msg.ImportFileReadError, ui.Msg.ImportFileParseError, ui.Msg.ImportSuccess) // PersistManager.ImportConfig kullan
-	default:
-		return fmt.Errorf(ui.Msg.UnknownConfigSubcommand, args[0])
 	}
+	sb.WriteString(shell.AdoptFunction(qa.Config.ShellType) + "\n")
+	return sb.String()
 }
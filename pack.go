@@ -0,0 +1,349 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"quickalias/internal/alias"
+	"quickalias/internal/ui"
+)
+
+// InstallPack installs a pack into the user's pack directory, replacing an installed pack
+// of the same name. source is a manifest, a directory or a .tar.gz archive, or the name of
+// a pack offered by a pack source (name@version for a given version), which is downloaded
+// and verified against the trusted keys first.
+func (qa *QuickAlias) InstallPack(source string) error {
+	var p alias.Pack
+	var err error
+	if name, version, remote := packReference(source); remote {
+		p, err = qa.fetchPack(name, version)
+	} else if p, err = alias.ReadPack(source); err != nil {
+		err = fmt.Errorf(ui.Msg.PackReadError, source, err)
+	}
+	if err != nil {
+		return err
+	}
+	return qa.installPack(p)
+}
+
+// installPack shows what p adds or changes and which of its aliases are overridden,
+// then installs it after confirmation.
+func (qa *QuickAlias) installPack(p alias.Pack) error {
+	if err := validateManifest(p.Aliases, qa.Config.ShellType, alias.PackLevel(p.Name)); err != nil {
+		return err
+	}
+
+	installed, replacing := alias.FindPack(p.Name, qa.PersistManager.Packs)
+	printPackHeader(p)
+	changes := alias.Diff(installed.Aliases, p.Aliases)
+	if replacing && len(changes) == 0 && installed.Version == p.Version {
+		fmt.Printf("%s✅ %s%s\n", ui.Color.Green, fmt.Sprintf(ui.Msg.PackUpToDate, p.Name), ui.Color.Reset)
+		return nil
+	}
+	alias.PrintChanges(changes)
+	qa.printPackOverrides(p)
+
+	prompt := fmt.Sprintf(ui.Msg.PackInstallConfirmation, p.Name, len(p.Aliases))
+	if replacing {
+		prompt = fmt.Sprintf(ui.Msg.PackReplaceConfirmation, p.Name, packVersion(installed), packVersion(p))
+	}
+	if err := ui.Confirm(fmt.Sprintf("%s%s%s", ui.Color.Yellow, prompt, ui.Color.Reset)); err != nil {
+		if errors.Is(err, ui.ErrCancelled) {
+			fmt.Printf("%s❌ %s%s\n", ui.Color.Red, ui.Msg.OperationCancelled, ui.Color.Reset)
+		}
+		return err
+	}
+	if err := qa.PersistManager.InstallPack(p); err != nil {
+		return err
+	}
+
+	fmt.Printf("%s✅ %s%s\n", ui.Color.Green, fmt.Sprintf(ui.Msg.PackInstalled, p.Name, len(p.Aliases)), ui.Color.Reset)
+	fmt.Printf("\n%s💡 %s %s%s%s\n", ui.Color.Cyan, ui.Msg.RestartTerminalHint, ui.Color.Bold, ui.Msg.RestartTerminalCmdHint, ui.Color.Reset)
+	return nil
+}
+
+// packReference reports whether source names a pack to download (name or name@version)
+// rather than a path: it does not exist on disk and looks like neither a path nor a file.
+func packReference(source string) (name, version string, remote bool) {
+	if _, err := os.Stat(source); err == nil || strings.ContainsAny(source, `/\`) {
+		return "", "", false
+	}
+	for _, ext := range []string{".json", ".yaml", ".yml", ".toml", ".tar.gz", ".tgz"} {
+		if strings.HasSuffix(strings.ToLower(source), ext) {
+			return "", "", false
+		}
+	}
+	name, version, _ = strings.Cut(source, "@")
+	return name, version, alias.IsPackName(name) && (version == "" || alias.IsPackName(version))
+}
+
+// fetchPack looks name up in the pack sources, in the order they were added, and
+// downloads the newest release (or the given version) from the first source that has it.
+func (qa *QuickAlias) fetchPack(name, version string) (alias.Pack, error) {
+	sources := alias.SplitSetting(qa.Config.Settings[alias.SettingPackSources])
+	if len(sources) == 0 {
+		return alias.Pack{}, errors.New(ui.Msg.PackNoSources)
+	}
+	keys, err := qa.trustedKeys()
+	if err != nil {
+		return alias.Pack{}, err
+	}
+	for _, source := range sources {
+		index, err := alias.FetchIndex(source)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s⚠️ %s%s\n", ui.Color.Yellow, fmt.Sprintf(ui.Msg.PackSourceUnavailable, source, err), ui.Color.Reset)
+			continue
+		}
+		if release, ok := index.Release(name, version); ok {
+			return qa.fetchRelease(release, keys)
+		}
+	}
+	if version != "" {
+		name += "@" + version
+	}
+	return alias.Pack{}, fmt.Errorf(ui.Msg.PackNotInSources, name)
+}
+
+// fetchRelease downloads and verifies a release (or takes it from the cache) and reads
+// the pack in it. The pack must be the release the index announced: the index is not
+// signed, so the name and version are taken from the signed manifest.
+func (qa *QuickAlias) fetchRelease(r alias.PackRelease, keys []alias.PublicKey) (alias.Pack, error) {
+	path, key, err := qa.PersistManager.FetchRelease(r, keys)
+	if err != nil {
+		return alias.Pack{}, err
+	}
+	p, err := alias.ReadPack(path)
+	if err != nil {
+		return alias.Pack{}, fmt.Errorf(ui.Msg.PackReadError, r.URL, err)
+	}
+	if p.Version == "" {
+		return alias.Pack{}, fmt.Errorf(ui.Msg.PackReleaseUnversioned, r.Name, r.Version)
+	}
+	if p.Name != r.Name || p.Version != r.Version {
+		return alias.Pack{}, fmt.Errorf(ui.Msg.PackReleaseMismatch, r.Name, r.Version, p.Name, p.Version)
+	}
+	p.Source = r.Source
+	fmt.Printf("%s🔒 %s%s\n", ui.Color.Green, fmt.Sprintf(ui.Msg.PackSignatureVerified, p.Name, p.Version, key.ID()), ui.Color.Reset)
+	return p, nil
+}
+
+// trustedKeys returns the public keys in the pack_trusted_keys setting.
+func (qa *QuickAlias) trustedKeys() ([]alias.PublicKey, error) {
+	var keys []alias.PublicKey
+	for _, text := range alias.SplitSetting(qa.Config.Settings[alias.SettingTrustedKeys]) {
+		key, err := alias.ParsePublicKey(text)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// addSetting appends value to the list setting key unless it is already there, and
+// reports whether it was added.
+func (qa *QuickAlias) addSetting(key, value string) bool {
+	if qa.Config.Settings == nil {
+		qa.Config.Settings = make(map[string]string)
+	}
+	values := alias.SplitSetting(qa.Config.Settings[key])
+	for _, v := range values {
+		if v == value {
+			return false
+		}
+	}
+	qa.Config.Settings[key] = strings.Join(append(values, value), ",")
+	return true
+}
+
+// AddPackSource adds the index at url to the pack sources after checking that it can be
+// read. key, if set, is added to the keys trusted to sign packs.
+func (qa *QuickAlias) AddPackSource(url, key string) error {
+	if key != "" {
+		if _, err := alias.ParsePublicKey(key); err != nil {
+			return err
+		}
+	}
+	index, err := alias.FetchIndex(url)
+	if err != nil {
+		return fmt.Errorf(ui.Msg.PackSourceUnavailable, url, err)
+	}
+
+	added := qa.addSetting(alias.SettingPackSources, url)
+	trusted := key != "" && qa.addSetting(alias.SettingTrustedKeys, key)
+	if added || trusted {
+		if err := qa.SaveConfig(); err != nil {
+			return err
+		}
+	}
+
+	if added {
+		fmt.Printf("%s✅ %s%s\n", ui.Color.Green, fmt.Sprintf(ui.Msg.PackSourceAdded, url, len(index.Packs)), ui.Color.Reset)
+	} else {
+		fmt.Printf("%s💡 %s%s\n", ui.Color.Cyan, fmt.Sprintf(ui.Msg.PackSourceExists, url), ui.Color.Reset)
+	}
+	if trusted {
+		fmt.Printf("%s🔑 %s%s\n", ui.Color.Green, ui.Msg.PackKeyTrusted, ui.Color.Reset)
+	}
+	if keys, _ := qa.trustedKeys(); len(keys) == 0 {
+		fmt.Printf("%s💡 %s%s\n", ui.Color.Cyan, ui.Msg.PackTrustHint, ui.Color.Reset)
+	}
+	return nil
+}
+
+// UpdatePacks upgrades installed packs (all, or those named) that were downloaded from a
+// pack source to the newest version their source offers. Every upgrade is verified,
+// shown and confirmed like an installation.
+func (qa *QuickAlias) UpdatePacks(names []string) error {
+	targets := qa.PersistManager.Packs
+	if len(names) > 0 {
+		targets = nil
+		for _, name := range names {
+			p, ok := alias.FindPack(name, qa.PersistManager.Packs)
+			if !ok {
+				return fmt.Errorf(ui.Msg.PackNotInstalled, name)
+			}
+			targets = append(targets, p)
+		}
+	}
+
+	indexes := make(map[string]alias.PackIndex)
+	var updates []alias.PackRelease
+	for _, p := range targets {
+		if p.Source == "" {
+			if len(names) > 0 {
+				fmt.Printf("%s%s%s\n", ui.Color.Dim, fmt.Sprintf(ui.Msg.PackNotFromSource, p.Name), ui.Color.Reset)
+			}
+			continue
+		}
+		index, ok := indexes[p.Source]
+		if !ok {
+			var err error
+			if index, err = alias.FetchIndex(p.Source); err != nil {
+				fmt.Fprintf(os.Stderr, "%s⚠️ %s%s\n", ui.Color.Yellow, fmt.Sprintf(ui.Msg.PackSourceUnavailable, p.Source, err), ui.Color.Reset)
+				continue
+			}
+			indexes[p.Source] = index
+		}
+		if release, ok := index.Release(p.Name, ""); ok && alias.CompareVersions(release.Version, p.Version) > 0 {
+			updates = append(updates, release)
+		}
+	}
+	if len(updates) == 0 {
+		fmt.Printf("%s✅ %s%s\n", ui.Color.Green, ui.Msg.PacksUpToDate, ui.Color.Reset)
+		return nil
+	}
+
+	keys, err := qa.trustedKeys()
+	if err != nil {
+		return err
+	}
+	for _, release := range updates {
+		p, err := qa.fetchRelease(release, keys)
+		if err != nil {
+			return err
+		}
+		if err := qa.installPack(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// printPackOverrides tells which aliases of p will not take effect: those the user has
+// defined, and those another pack that sorts before p already provides.
+func (qa *QuickAlias) printPackOverrides(p alias.Pack) {
+	for _, a := range p.Aliases {
+		if _, ok := alias.FindAlias(a.Name, qa.UserAliases); ok {
+			fmt.Printf("  %s%s%s\n", ui.Color.Dim, fmt.Sprintf(ui.Msg.PackOverriddenByUser, a.Name), ui.Color.Reset)
+			continue
+		}
+		for _, other := range qa.PersistManager.Packs {
+			if _, ok := alias.FindAlias(a.Name, other.Aliases); ok && other.Name != p.Name && other.Name < p.Name {
+				fmt.Printf("  %s%s%s\n", ui.Color.Dim, fmt.Sprintf(ui.Msg.PackOverriddenByPack, a.Name, other.Name), ui.Color.Reset)
+				break
+			}
+		}
+	}
+}
+
+// ListPacks prints the installed packs with their metadata and how many of their
+// aliases are overridden.
+func (qa *QuickAlias) ListPacks() error {
+	list := alias.PackList{}
+	for _, p := range qa.PersistManager.Packs {
+		info := alias.PackInfo{Name: p.Name, Version: p.Version, Description: p.Description, Author: p.Author,
+			Source: p.Source, Aliases: len(p.Aliases)}
+		for _, a := range p.Aliases {
+			effective, _ := alias.FindAlias(a.Name, qa.PackAliases)
+			if _, ok := alias.FindAlias(a.Name, qa.UserAliases); ok || effective.Level != a.Level {
+				info.Overridden++
+			}
+		}
+		list = append(list, info)
+	}
+	if !qa.Output.IsText() {
+		return qa.Output.Render(os.Stdout, list)
+	}
+
+	if len(list) == 0 {
+		fmt.Printf("%s%s%s\n", ui.Color.Yellow, ui.Msg.PacksNotInstalled, ui.Color.Reset)
+		return nil
+	}
+	for i, p := range qa.PersistManager.Packs {
+		printPackHeader(p)
+		details := fmt.Sprintf(ui.Msg.PackAliasCount, list[i].Aliases)
+		if list[i].Overridden > 0 {
+			details += ", " + fmt.Sprintf(ui.Msg.PackOverriddenCount, list[i].Overridden)
+		}
+		if p.Author != "" {
+			details += " · " + p.Author
+		}
+		fmt.Printf("   %s%s%s\n", ui.Color.White, details, ui.Color.Reset)
+	}
+	return nil
+}
+
+// RemovePack uninstalls the pack called name. User aliases that overrode its aliases are kept.
+func (qa *QuickAlias) RemovePack(name string) error {
+	p, ok := alias.FindPack(name, qa.PersistManager.Packs)
+	if !ok {
+		return fmt.Errorf(ui.Msg.PackNotInstalled, name)
+	}
+	prompt := fmt.Sprintf(ui.Msg.PackRemoveConfirmation, name, len(p.Aliases))
+	if err := ui.Confirm(fmt.Sprintf("%s%s%s", ui.Color.Yellow, prompt, ui.Color.Reset)); err != nil {
+		if errors.Is(err, ui.ErrCancelled) {
+			fmt.Printf("%s❌ %s%s\n", ui.Color.Red, ui.Msg.OperationCancelled, ui.Color.Reset)
+		}
+		return err
+	}
+	if err := qa.PersistManager.RemovePack(name); err != nil {
+		return err
+	}
+
+	fmt.Printf("%s✅ %s%s\n", ui.Color.Green, fmt.Sprintf(ui.Msg.PackRemoved, name), ui.Color.Reset)
+	fmt.Printf("\n%s💡 %s %s%s%s\n", ui.Color.Cyan, ui.Msg.RestartTerminalHint, ui.Color.Bold, ui.Msg.RestartTerminalCmdHint, ui.Color.Reset)
+	return nil
+}
+
+// printPackHeader prints the name, version and description of a pack.
+func printPackHeader(p alias.Pack) {
+	fmt.Printf("%s📦 %s%s", ui.Color.Cyan+ui.Color.Bold, p.Name, ui.Color.Reset)
+	if p.Version != "" {
+		fmt.Printf(" %s%s%s", ui.Color.Purple, p.Version, ui.Color.Reset)
+	}
+	if p.Description != "" {
+		fmt.Printf("  %s— %s%s", ui.Color.White, p.Description, ui.Color.Reset)
+	}
+	fmt.Println()
+}
+
+// packVersion returns the version of p for messages, or a placeholder when it has none.
+func packVersion(p alias.Pack) string {
+	if p.Version == "" {
+		return ui.Msg.PackNoVersion
+	}
+	return p.Version
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"quickalias/internal/alias"
+	"quickalias/internal/config"
+	"quickalias/internal/ui"
+)
+
+// newTestQuickAlias returns a QuickAlias whose user and global layers live in a temporary
+// directory, answering yes to every confirmation.
+func newTestQuickAlias(t *testing.T) *QuickAlias {
+	t.Helper()
+	dir := t.TempDir()
+	qa := &QuickAlias{
+		UserConfigPath:   filepath.Join(dir, "user"),
+		GlobalConfigPath: filepath.Join(dir, "global"),
+		UserAliases:      []alias.Alias{},
+		GlobalAliases:    []alias.Alias{},
+		PackAliases:      []alias.Alias{},
+		Config:           config.Config{Version: VERSION, ShellType: "bash", Settings: make(map[string]string)},
+	}
+	qa.PersistManager = alias.NewPersistManager(qa.UserConfigPath, qa.GlobalConfigPath, &qa.UserAliases, &qa.GlobalAliases, &qa.PackAliases)
+	if err := qa.PersistManager.MkdirUser(qa.UserConfigPath); err != nil {
+		t.Fatal(err)
+	}
+
+	assumeYes := ui.Prompt.AssumeYes
+	ui.Prompt.AssumeYes = true
+	t.Cleanup(func() { ui.Prompt.AssumeYes = assumeYes })
+	return qa
+}
+
+// packServer serves a pack index and signed pack releases, like a static pack source.
+type packServer struct {
+	*httptest.Server
+	mu    sync.Mutex
+	files map[string][]byte
+	index alias.PackIndex
+}
+
+func newPackServer(t *testing.T) *packServer {
+	s := &packServer{files: make(map[string][]byte)}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		data, ok := s.files[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(data)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// publish adds a release of a pack with one alias, signed with minisign's legacy "Ed"
+// algorithm by signer, and lists it in the index.
+func (s *packServer) publish(t *testing.T, name, version, command string, signer ed25519.PrivateKey) {
+	t.Helper()
+	s.publishManifest(t, name, version, alias.Pack{Name: name, Version: version, Aliases: []alias.Alias{{Name: name + "s", Command: command}}}, signer)
+}
+
+// publishManifest lists a release of name at version in the index and serves p, signed
+// by signer, as its file.
+func (s *packServer) publishManifest(t *testing.T, name, version string, p alias.Pack, signer ed25519.PrivateKey) {
+	t.Helper()
+	manifest, err := json.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig := append([]byte("Ed\x01\x02\x03\x04\x05\x06\x07\x08"), ed25519.Sign(signer, manifest)...)
+	comment := "timestamp:1792310400\tfile:" + name + ".json"
+	global := ed25519.Sign(signer, append(append([]byte{}, sig[10:]...), comment...))
+	signature := fmt.Sprintf("untrusted comment: signature from minisign secret key\n%s\ntrusted comment: %s\n%s\n",
+		base64.StdEncoding.EncodeToString(sig), comment, base64.StdEncoding.EncodeToString(global))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	file := "/" + name + "-" + version + ".json"
+	s.files[file] = manifest
+	s.files[file+".minisig"] = []byte(signature)
+	s.index.Packs = append(s.index.Packs, alias.PackRelease{Name: name, Version: version, URL: file})
+	if s.files["/index.json"], err = json.Marshal(s.index); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// minisignKey returns the minisign public key of private with the key ID publish uses.
+func minisignKey(private ed25519.PrivateKey) string {
+	return base64.StdEncoding.EncodeToString(append([]byte("Ed\x01\x02\x03\x04\x05\x06\x07\x08"), private.Public().(ed25519.PublicKey)...))
+}
+
+func TestPackSourceRoundTrip(t *testing.T) {
+	key := ed25519.NewKeyFromSeed([]byte(strings.Repeat("k", ed25519.SeedSize)))
+	server := newPackServer(t)
+	server.publish(t, "demo", "1.0.0", "git status", key)
+	qa := newTestQuickAlias(t)
+	indexURL := server.URL + "/index.json"
+
+	if err := qa.AddPackSource(indexURL, minisignKey(key)); err != nil {
+		t.Fatalf("AddPackSource: %v", err)
+	}
+	var saved config.Config
+	config.LoadConfig(qa.UserConfigPath, &saved)
+	if saved.Settings[alias.SettingPackSources] != indexURL || saved.Settings[alias.SettingTrustedKeys] != minisignKey(key) {
+		t.Fatalf("saved settings = %v", saved.Settings)
+	}
+
+	if err := qa.InstallPack("demo"); err != nil {
+		t.Fatalf("InstallPack: %v", err)
+	}
+	installed, ok := alias.FindPack("demo", qa.PersistManager.Packs)
+	if !ok || installed.Version != "1.0.0" || installed.Source != indexURL {
+		t.Fatalf("installed = %+v, %v", installed, ok)
+	}
+	if a, ok := alias.FindAlias("demos", qa.PackAliases); !ok || a.Command != "git status" {
+		t.Errorf("pack alias = %+v, %v", a, ok)
+	}
+
+	server.publish(t, "demo", "1.1.0", "git status -sb", key)
+	if err := qa.UpdatePacks(nil); err != nil {
+		t.Fatalf("UpdatePacks: %v", err)
+	}
+	installed, _ = alias.FindPack("demo", qa.PersistManager.Packs)
+	if installed.Version != "1.1.0" {
+		t.Errorf("version after update = %s, want 1.1.0", installed.Version)
+	}
+	if a, _ := alias.FindAlias("demos", qa.PackAliases); a.Command != "git status -sb" {
+		t.Errorf("pack alias after update = %q", a.Command)
+	}
+
+	// A release signed with a key that is not trusted is refused and changes nothing.
+	other := ed25519.NewKeyFromSeed([]byte(strings.Repeat("x", ed25519.SeedSize)))
+	server.publish(t, "demo", "1.2.0", "rm -rf ~", other)
+	if err := qa.UpdatePacks(nil); err == nil {
+		t.Fatal("UpdatePacks installed a release signed with an untrusted key")
+	}
+	if installed, _ = alias.FindPack("demo", qa.PersistManager.Packs); installed.Version != "1.1.0" {
+		t.Errorf("version after refused update = %s, want 1.1.0", installed.Version)
+	}
+}
+
+func TestPackReleaseMustMatchIndex(t *testing.T) {
+	key := ed25519.NewKeyFromSeed([]byte(strings.Repeat("k", ed25519.SeedSize)))
+	aliases := []alias.Alias{{Name: "demos", Command: "git status"}}
+	tests := []struct {
+		name string
+		pack alias.Pack
+		err  string
+	}{
+		{"matching", alias.Pack{Name: "demo", Version: "2.0.0", Aliases: aliases}, ""},
+		{"unversioned", alias.Pack{Name: "demo", Aliases: aliases}, "has no version"},
+		{"older version", alias.Pack{Name: "demo", Version: "1.0.0", Aliases: aliases}, "holds demo 1.0.0"},
+		{"other pack", alias.Pack{Name: "other", Version: "2.0.0", Aliases: aliases}, "holds other 2.0.0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newPackServer(t)
+			server.publishManifest(t, "demo", "2.0.0", tt.pack, key)
+			qa := newTestQuickAlias(t)
+			if err := qa.AddPackSource(server.URL+"/index.json", minisignKey(key)); err != nil {
+				t.Fatal(err)
+			}
+
+			err := qa.InstallPack("demo")
+			if tt.err == "" {
+				if err != nil {
+					t.Fatalf("InstallPack: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Fatalf("InstallPack: err = %v, want %q", err, tt.err)
+			}
+			if len(qa.PersistManager.Packs) != 0 {
+				t.Errorf("installed %+v", qa.PersistManager.Packs)
+			}
+		})
+	}
+}
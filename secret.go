@@ -0,0 +1,154 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"quickalias/internal/alias"
+	"quickalias/internal/ui"
+)
+
+// SetSecret stores a secret for ${secret:name} placeholders in the secret file. The value
+// is read from standard input when it is piped, and asked for without echo otherwise, so
+// it never appears on the command line or in the shell history.
+func (qa *QuickAlias) SetSecret(name string) error {
+	if !alias.IsSecretName(name) {
+		return fmt.Errorf(ui.Msg.SecretInvalidName, name)
+	}
+
+	var value string
+	if ui.IsTerminal(os.Stdin) {
+		v, err := ui.AskSecret(fmt.Sprintf(ui.Msg.SecretPrompt, name))
+		if err != nil {
+			return err
+		}
+		value = v
+	} else {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return err
+		}
+		value = strings.TrimRight(string(data), "\r\n")
+	}
+	if value == "" {
+		return errors.New(ui.Msg.SecretEmpty)
+	}
+	if strings.ContainsAny(value, "\r\n") {
+		return errors.New(ui.Msg.SecretMultiline)
+	}
+
+	replaced, err := qa.PersistManager.SetSecret(name, &value)
+	if err != nil {
+		return fmt.Errorf(ui.Msg.SecretWriteError, err)
+	}
+	msg := ui.Msg.SecretSaved
+	if replaced {
+		msg = ui.Msg.SecretReplaced
+	}
+	fmt.Printf("%s🔑 %s%s\n", ui.Color.Green, fmt.Sprintf(msg, name, qa.PersistManager.SecretsPath()), ui.Color.Reset)
+	fmt.Printf("   %s%s%s\n", ui.Color.White, fmt.Sprintf(ui.Msg.SecretUsageHint, name), ui.Color.Reset)
+	return nil
+}
+
+// RemoveSecret deletes a secret from the secret file.
+func (qa *QuickAlias) RemoveSecret(name string) error {
+	removed, err := qa.PersistManager.SetSecret(name, nil)
+	if err != nil {
+		return fmt.Errorf(ui.Msg.SecretWriteError, err)
+	}
+	if !removed {
+		return fmt.Errorf(ui.Msg.SecretNotInFile, name)
+	}
+	fmt.Printf("%s✅ %s%s\n", ui.Color.Green, fmt.Sprintf(ui.Msg.SecretRemoved, name), ui.Color.Reset)
+	for _, a := range qa.secretUsers(name) {
+		fmt.Printf("%s⚠️  %s%s\n", ui.Color.Yellow, fmt.Sprintf(ui.Msg.SecretStillUsed, a.Name, name), ui.Color.Reset)
+	}
+	return nil
+}
+
+// ListSecrets shows the names of the secrets in the secret file and of the secrets aliases
+// refer to, with the aliases using each. Values are never shown.
+func (qa *QuickAlias) ListSecrets() error {
+	stored, err := alias.ReadSecretFile(qa.PersistManager.SecretsPath())
+	if err != nil {
+		fmt.Printf("%s⚠️  %v%s\n", ui.Color.Yellow, err, ui.Color.Reset)
+	}
+
+	names := alias.SortedSecretNames(stored)
+	seen := make(map[string]bool)
+	for _, n := range names {
+		seen[n] = true
+	}
+	for _, a := range qa.allAliases() {
+		for _, n := range alias.SecretNames(a.Command) {
+			if !seen[n] {
+				seen[n] = true
+				names = append(names, n)
+			}
+		}
+	}
+	if len(names) == 0 {
+		fmt.Printf("%s%s%s\n", ui.Color.Yellow, ui.Msg.SecretsNone, ui.Color.Reset)
+		return nil
+	}
+
+	for _, n := range names {
+		where := ui.Msg.SecretInFile
+		if _, ok := stored[n]; !ok {
+			where = ui.Msg.SecretExternal
+		}
+		fmt.Printf("%s🔑 %s%s  %s%s%s\n", ui.Color.Cyan+ui.Color.Bold, n, ui.Color.Reset, ui.Color.White, where, ui.Color.Reset)
+		var users []string
+		for _, a := range qa.secretUsers(n) {
+			users = append(users, a.Name)
+		}
+		if len(users) == 0 {
+			fmt.Printf("   %s%s%s\n", ui.Color.Dim, ui.Msg.SecretUnused, ui.Color.Reset)
+		} else {
+			fmt.Printf("   %s%s%s\n", ui.Color.White, fmt.Sprintf(ui.Msg.SecretUsedBy, strings.Join(users, ", ")), ui.Color.Reset)
+		}
+	}
+	return nil
+}
+
+// allAliases returns the aliases of every layer.
+func (qa *QuickAlias) allAliases() []alias.Alias {
+	all := append(append([]alias.Alias{}, qa.UserAliases...), qa.PackAliases...)
+	return append(all, qa.GlobalAliases...)
+}
+
+// secretUsers returns the aliases whose command refers to the secret called name.
+func (qa *QuickAlias) secretUsers(name string) []alias.Alias {
+	var users []alias.Alias
+	for _, a := range qa.allAliases() {
+		for _, n := range alias.SecretNames(a.Command) {
+			if n == name {
+				users = append(users, a)
+				break
+			}
+		}
+	}
+	return users
+}
+
+// resolveSecrets replaces the secret placeholders of user aliases with the secrets' values
+// for qq init. Pack and global aliases never get a secret: those using one are left out,
+// like user aliases whose secrets cannot be found, with a warning on standard error, which
+// the shell shows while standard output is evaluated.
+func (qa *QuickAlias) resolveSecrets() (user, pack, global []alias.Alias) {
+	resolver := alias.NewSecretResolver(qa.PersistManager.SecretsPath())
+	user, errs := alias.ResolveSecrets(qa.UserAliases, resolver)
+	pack, packErrs := alias.WithoutSecrets(qa.PackAliases, "pack")
+	global, globalErrs := alias.WithoutSecrets(qa.GlobalAliases, "global")
+	errs = append(append(errs, packErrs...), globalErrs...)
+	if resolver.FileErr != nil {
+		fmt.Fprintf(os.Stderr, "%s⚠️  qq: %v%s\n", ui.Color.Yellow, resolver.FileErr, ui.Color.Reset)
+	}
+	for _, err := range errs {
+		fmt.Fprintf(os.Stderr, "%s⚠️  qq: %v%s\n", ui.Color.Yellow, err, ui.Color.Reset)
+	}
+	return user, pack, global
+}